@@ -0,0 +1,151 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BlobStoreSpec определяет желаемое состояние хранилища blob-объектов Nexus.
+// +kubebuilder:validation:XValidation:rule="has(self.file) == (self.type == 'File')",message="настройки file обязательны для хранилища типа File и неприменимы к остальным"
+// +kubebuilder:validation:XValidation:rule="has(self.s3) == (self.type == 'S3')",message="настройки s3 обязательны для хранилища типа S3 и неприменимы к остальным"
+type BlobStoreSpec struct {
+	// Name - название хранилища в Nexus (неизменяемое).
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9\-]{1}[a-zA-Z0-9_\-\.]*$`
+	// +kubebuilder:validation:Immutable
+	Name string `json:"name"`
+
+	// Type - тип хранилища: File или S3 (неизменяемое).
+	// +kubebuilder:validation:Enum=File;S3
+	// +kubebuilder:validation:Immutable
+	Type string `json:"type"`
+
+	// File - настройки файлового хранилища.
+	// +optional
+	File *FileBlobStoreConfig `json:"file,omitempty"`
+
+	// S3 - настройки хранилища в S3 или S3-совместимом сервисе (например, MinIO).
+	// +optional
+	S3 *S3BlobStoreConfig `json:"s3,omitempty"`
+
+	// SoftQuota - мягкая квота: при её превышении Nexus предупреждает, но не запрещает запись.
+	// +optional
+	SoftQuota *BlobStoreSoftQuota `json:"softQuota,omitempty"`
+
+	// InstanceRef - ссылка на экземпляр Nexus.
+	// Если не задана, используется экземпляр по умолчанию из ENV-переменных.
+	// +optional
+	InstanceRef *InstanceReference `json:"instanceRef,omitempty"`
+}
+
+// FileBlobStoreConfig - настройки файлового хранилища.
+type FileBlobStoreConfig struct {
+	// Path - абсолютный путь или путь относительно каталога sonatype-work/nexus3/blobs.
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+}
+
+// S3BlobStoreConfig - настройки хранилища S3.
+type S3BlobStoreConfig struct {
+	// Bucket - имя бакета. Nexus создаёт бакет, если его нет.
+	// +kubebuilder:validation:MinLength=3
+	Bucket string `json:"bucket"`
+
+	// Prefix - префикс объектов в бакете.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Region - регион AWS. DEFAULT - регион по умолчанию из окружения Nexus.
+	// +kubebuilder:default=DEFAULT
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Endpoint - адрес S3-совместимого сервиса, например http://minio.minio.svc:9000.
+	// Если не задан, используется AWS S3.
+	// +kubebuilder:validation:Pattern=`^(http|https)://.+`
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// ForcePathStyle - адресация бакета в пути запроса вместо поддомена, требуется для MinIO.
+	// +optional
+	ForcePathStyle bool `json:"forcePathStyle,omitempty"`
+
+	// Expiration - через сколько дней удалённые объекты удаляются из бакета; -1 отключает удаление.
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=-1
+	// +optional
+	Expiration *int32 `json:"expiration,omitempty"`
+
+	// CredentialsSecretRef - Secret с ключом доступа в пространстве имён ресурса.
+	// Если не задан, Nexus использует учётные данные из своего окружения (например, роль IAM).
+	// +optional
+	CredentialsSecretRef *S3CredentialsSecretReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// S3CredentialsSecretReference - ссылка на Secret с ключом доступа S3.
+type S3CredentialsSecretReference struct {
+	// Name - имя Secret.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// AccessKeyIDKey - ключ Secret с идентификатором ключа доступа.
+	// +kubebuilder:default=accessKeyId
+	// +optional
+	AccessKeyIDKey string `json:"accessKeyIdKey,omitempty"`
+
+	// SecretAccessKeyKey - ключ Secret с секретным ключом доступа.
+	// +kubebuilder:default=secretAccessKey
+	// +optional
+	SecretAccessKeyKey string `json:"secretAccessKeyKey,omitempty"`
+}
+
+// BlobStoreSoftQuota - мягкая квота хранилища.
+type BlobStoreSoftQuota struct {
+	// Type - тип квоты: spaceRemainingQuota (минимум свободного места) или spaceUsedQuota (максимум занятого).
+	// +kubebuilder:validation:Enum=spaceRemainingQuota;spaceUsedQuota
+	Type string `json:"type"`
+
+	// Limit - предел квоты, например 100Gi.
+	Limit resource.Quantity `json:"limit"`
+}
+
+// BlobStoreStatus описывает состояние хранилища.
+type BlobStoreStatus struct {
+	// Conditions содержит список условий, описывающих состояние ресурса.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// CredentialsHash - хэш ключа доступа S3, применённого в Nexus.
+	// Позволяет повторно применить ключ после изменения Secret.
+	// +optional
+	CredentialsHash string `json:"credentialsHash,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Name",type="string",JSONPath=".spec.name"
+//+kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type"
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// BlobStore - хранилище blob-объектов Nexus, в котором репозитории хранят содержимое.
+type BlobStore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BlobStoreSpec   `json:"spec,omitempty"`
+	Status BlobStoreStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// BlobStoreList содержит список BlobStore.
+type BlobStoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BlobStore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BlobStore{}, &BlobStoreList{})
+}
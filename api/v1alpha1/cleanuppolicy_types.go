@@ -0,0 +1,94 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CleanupPolicySpec определяет желаемое состояние политики очистки Nexus.
+// +kubebuilder:validation:XValidation:rule="has(self.criteria.lastDownloaded) || has(self.criteria.lastBlobUpdated) || has(self.criteria.releaseType) || has(self.criteria.assetRegex)",message="требуется хотя бы один критерий очистки"
+type CleanupPolicySpec struct {
+	// Name - название политики очистки в Nexus (неизменяемое).
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9\-]{1}[a-zA-Z0-9_\-\.]*$`
+	// +kubebuilder:validation:Immutable
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="name неизменяемое"
+	Name string `json:"name"`
+
+	// Format - формат репозиториев, к которым применима политика, в терминах Nexus
+	// (например, maven2, npm, docker).
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Format string `json:"format"`
+
+	// Notes - описание политики.
+	// +optional
+	Notes string `json:"notes,omitempty"`
+
+	// Criteria - критерии отбора компонентов для удаления. Компонент удаляется,
+	// если он соответствует всем заданным критериям.
+	// +kubebuilder:validation:Required
+	Criteria CleanupPolicyCriteria `json:"criteria"`
+
+	// InstanceRef - ссылка на экземпляр Nexus.
+	// Если не задана, используется экземпляр по умолчанию из ENV-переменных.
+	// +optional
+	InstanceRef *InstanceReference `json:"instanceRef,omitempty"`
+}
+
+// CleanupPolicyCriteria - критерии политики очистки.
+type CleanupPolicyCriteria struct {
+	// LastDownloaded - число дней с последнего скачивания компонента.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	LastDownloaded *int32 `json:"lastDownloaded,omitempty"`
+
+	// LastBlobUpdated - число дней с публикации компонента.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	LastBlobUpdated *int32 `json:"lastBlobUpdated,omitempty"`
+
+	// ReleaseType - тип версий: PRERELEASES (только пре-релизы) или RELEASES (только релизы).
+	// +kubebuilder:validation:Enum=RELEASES;PRERELEASES
+	// +optional
+	ReleaseType string `json:"releaseType,omitempty"`
+
+	// AssetRegex - регулярное выражение для путей ресурсов компонента.
+	// +optional
+	AssetRegex string `json:"assetRegex,omitempty"`
+}
+
+// CleanupPolicyStatus описывает состояние политики очистки.
+type CleanupPolicyStatus struct {
+	// Conditions содержит список условий, описывающих состояние ресурса.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Name",type="string",JSONPath=".spec.name"
+//+kubebuilder:printcolumn:name="Format",type="string",JSONPath=".spec.format"
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// CleanupPolicy - политика очистки компонентов в репозиториях Nexus.
+type CleanupPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CleanupPolicySpec   `json:"spec,omitempty"`
+	Status CleanupPolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CleanupPolicyList содержит список CleanupPolicy.
+type CleanupPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CleanupPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CleanupPolicy{}, &CleanupPolicyList{})
+}
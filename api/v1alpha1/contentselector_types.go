@@ -33,6 +33,11 @@ type ContentSelectorSpec struct {
 
 	// Выражение для выбора контента.
 	Expression string `json:"expression"`
+
+	// Ссылка на экземпляр Nexus (опционально).
+	// Если не задана, используется экземпляр по умолчанию из ENV-переменных.
+	// +optional
+	InstanceRef *InstanceReference `json:"instanceRef,omitempty"`
 }
 
 // ContentSelectorStatus определяет текущее состояние Content Selector.
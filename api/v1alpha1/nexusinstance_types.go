@@ -0,0 +1,357 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// NexusInstanceKind - тип пространственного экземпляра Nexus.
+	NexusInstanceKind = "NexusInstance"
+	// ClusterNexusInstanceKind - тип кластерного экземпляра Nexus.
+	ClusterNexusInstanceKind = "ClusterNexusInstance"
+)
+
+// NexusInstanceSpec определяет параметры подключения оператора к серверу Nexus.
+type NexusInstanceSpec struct {
+	// URL - базовый адрес Nexus (например, https://nexus.example.com).
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^(http|https)://.+`
+	URL string `json:"url"`
+
+	// CredentialsSecretRef - ссылка на Secret с учётными данными пользователя Nexus.
+	// +kubebuilder:validation:Required
+	CredentialsSecretRef CredentialsSecretReference `json:"credentialsSecretRef"`
+
+	// ClientOptions содержит настройки HTTP-клиента оператора (опционально).
+	// +optional
+	ClientOptions *ClientOptions `json:"clientOptions,omitempty"`
+
+	// TLS содержит настройки TLS-соединения с Nexus (опционально).
+	// +optional
+	TLS *TLSConfig `json:"tls,omitempty"`
+
+	// DockerPorts - диапазон портов для коннекторов Docker-репозиториев со значением порта auto (опционально).
+	// +optional
+	DockerPorts *PortRange `json:"dockerPorts,omitempty"`
+
+	// DockerExposure - шаблон Service и Ingress или HTTPRoute для Docker-репозиториев
+	// с docker.expose (опционально).
+	// +optional
+	DockerExposure *DockerExposureTemplate `json:"dockerExposure,omitempty"`
+}
+
+// DockerExposureTemplate описывает, как публиковать коннекторы Docker-репозиториев в кластере.
+// Для каждого репозитория создаётся Service на порт коннектора и, если задан шаблон,
+// Ingress или HTTPRoute с хостом <subdomain>.<domain> (по умолчанию subdomain - имя ресурса Repository).
+// +kubebuilder:validation:XValidation:rule="!(has(self.ingress) && has(self.httpRoute))",message="должен быть указан только один из ingress или httpRoute"
+// +kubebuilder:validation:XValidation:rule="(!has(self.ingress) && !has(self.httpRoute)) || has(self.domain)",message="для ingress и httpRoute требуется domain"
+type DockerExposureTemplate struct {
+	// Namespace - пространство имён подов Nexus, в котором создаются объекты.
+	// По умолчанию используется пространство имён репозитория. Задаётся только для ClusterNexusInstance.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Selector - метки подов Nexus для Service.
+	// +kubebuilder:validation:MinProperties=1
+	Selector map[string]string `json:"selector"`
+
+	// Domain - домен для имён хостов Ingress и HTTPRoute.
+	// +optional
+	Domain string `json:"domain,omitempty"`
+
+	// NexusPort - основной порт Nexus, используемый для репозиториев без порта коннектора
+	// (доступ по поддомену).
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default=8081
+	// +optional
+	NexusPort int32 `json:"nexusPort,omitempty"`
+
+	// Ingress - шаблон Ingress.
+	// +optional
+	Ingress *DockerIngressTemplate `json:"ingress,omitempty"`
+
+	// HTTPRoute - шаблон HTTPRoute (Gateway API).
+	// +optional
+	HTTPRoute *DockerHTTPRouteTemplate `json:"httpRoute,omitempty"`
+}
+
+// DockerIngressTemplate - шаблон Ingress для Docker-репозитория.
+type DockerIngressTemplate struct {
+	// ClassName - класс Ingress.
+	// +optional
+	ClassName string `json:"className,omitempty"`
+
+	// Annotations - аннотации Ingress (например, ограничение размера тела запроса).
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// TLSSecretName - Secret с сертификатом для хоста репозитория.
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+}
+
+// DockerHTTPRouteTemplate - шаблон HTTPRoute для Docker-репозитория.
+type DockerHTTPRouteTemplate struct {
+	// ParentRefs - шлюзы, к которым подключается HTTPRoute.
+	// +kubebuilder:validation:MinItems=1
+	ParentRefs []GatewayParentReference `json:"parentRefs"`
+
+	// Annotations - аннотации HTTPRoute.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// GatewayParentReference - ссылка на Gateway.
+type GatewayParentReference struct {
+	// Name - имя Gateway.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace - пространство имён Gateway. По умолчанию совпадает с пространством имён HTTPRoute.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName - имя слушателя Gateway.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// PortRange - диапазон портов, включая границы.
+// +kubebuilder:validation:XValidation:rule="self.from <= self.to",message="from не может быть больше to"
+type PortRange struct {
+	// From - первый порт диапазона.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	From int32 `json:"from"`
+
+	// To - последний порт диапазона.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	To int32 `json:"to"`
+}
+
+// TLSConfig определяет параметры TLS для подключения к Nexus.
+type TLSConfig struct {
+	// CA - источник PEM-бандла доверенных центров сертификации.
+	// Сертификаты добавляются к системному хранилищу.
+	// +optional
+	CA *CABundleSource `json:"ca,omitempty"`
+
+	// ClientCertSecretRef - Secret типа kubernetes.io/tls (ключи tls.crt и tls.key)
+	// с клиентским сертификатом для mTLS.
+	// +optional
+	ClientCertSecretRef *ObjectReference `json:"clientCertSecretRef,omitempty"`
+
+	// ServerName переопределяет имя сервера для SNI и проверки сертификата.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+
+	// MinVersion - минимальная версия TLS.
+	// +kubebuilder:validation:Enum=TLS12;TLS13
+	// +kubebuilder:default=TLS12
+	// +optional
+	MinVersion string `json:"minVersion,omitempty"`
+
+	// InsecureSkipVerify отключает проверку сертификата сервера (только для отладки).
+	// +kubebuilder:default=false
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// CABundleSource определяет, откуда загружается бандл центров сертификации.
+// Должен быть указан ровно один источник.
+// +kubebuilder:validation:XValidation:rule="has(self.configMapRef) != has(self.secretRef)",message="должен быть указан ровно один из configMapRef или secretRef"
+type CABundleSource struct {
+	// ConfigMapRef - ключ ConfigMap с PEM-бандлом.
+	// +optional
+	ConfigMapRef *KeyReference `json:"configMapRef,omitempty"`
+
+	// SecretRef - ключ Secret с PEM-бандлом.
+	// +optional
+	SecretRef *KeyReference `json:"secretRef,omitempty"`
+}
+
+// ObjectReference - ссылка на объект Kubernetes по имени.
+type ObjectReference struct {
+	// Name - имя объекта.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace - пространство имён объекта.
+	// Для NexusInstance не задаётся: объект берётся из пространства имён экземпляра.
+	// Для ClusterNexusInstance является обязательным.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// KeyReference - ссылка на ключ в ConfigMap или Secret.
+type KeyReference struct {
+	ObjectReference `json:",inline"`
+
+	// Key - ключ с данными.
+	// +kubebuilder:default=ca.crt
+	Key string `json:"key,omitempty"`
+}
+
+// CredentialsSecretReference описывает Secret с учётными данными Nexus.
+type CredentialsSecretReference struct {
+	ObjectReference `json:",inline"`
+
+	// UsernameKey - ключ Secret, содержащий имя пользователя.
+	// +kubebuilder:default=username
+	UsernameKey string `json:"usernameKey,omitempty"`
+
+	// PasswordKey - ключ Secret, содержащий пароль.
+	// +kubebuilder:default=password
+	PasswordKey string `json:"passwordKey,omitempty"`
+}
+
+// ClientOptions определяет настройки HTTP-клиента для API Nexus.
+type ClientOptions struct {
+	// Timeout - таймаут одного запроса к API Nexus.
+	// +kubebuilder:default="30s"
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Debug включает подробное логирование запросов и ответов.
+	// +kubebuilder:default=false
+	// +optional
+	Debug bool `json:"debug,omitempty"`
+
+	// RateLimit ограничивает частоту запросов оператора к экземпляру.
+	// +optional
+	RateLimit *RateLimitOptions `json:"rateLimit,omitempty"`
+
+	// CircuitBreaker задаёт параметры автоматического выключателя.
+	// +optional
+	CircuitBreaker *CircuitBreakerOptions `json:"circuitBreaker,omitempty"`
+}
+
+// RateLimitOptions - параметры ограничителя частоты запросов (token bucket).
+type RateLimitOptions struct {
+	// QPS - среднее число запросов в секунду.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=10
+	// +optional
+	QPS int32 `json:"qps,omitempty"`
+
+	// Burst - допустимое число запросов сверх среднего.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=20
+	// +optional
+	Burst int32 `json:"burst,omitempty"`
+}
+
+// CircuitBreakerOptions - параметры автоматического выключателя.
+// После FailureThreshold неудачных запросов подряд (429, 5xx, сетевые ошибки) обращения
+// к Nexus приостанавливаются на OpenTimeout, затем выполняется пробный запрос.
+type CircuitBreakerOptions struct {
+	// FailureThreshold - число неудачных запросов подряд до размыкания.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=5
+	// +optional
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+
+	// OpenTimeout - пауза перед пробным запросом.
+	// +kubebuilder:default="30s"
+	// +optional
+	OpenTimeout *metav1.Duration `json:"openTimeout,omitempty"`
+}
+
+// NexusInstanceStatus описывает состояние подключения к экземпляру Nexus.
+type NexusInstanceStatus struct {
+	// Conditions содержит список условий, описывающих состояние ресурса.
+	// +optional
+	Conditions         []metav1.Condition `json:"conditions,omitempty"`
+	ObservedGeneration int64              `json:"observedGeneration,omitempty"`
+
+	// Version - обнаруженная версия Nexus.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Edition - обнаруженная редакция Nexus (OSS, PRO или COMMUNITY).
+	// +optional
+	Edition string `json:"edition,omitempty"`
+}
+
+// InstanceReference - ссылка на экземпляр Nexus, в котором управляется ресурс.
+type InstanceReference struct {
+	// Kind - тип экземпляра: NexusInstance (в том же пространстве имён, что и ресурс)
+	// или ClusterNexusInstance.
+	// +kubebuilder:validation:Enum=NexusInstance;ClusterNexusInstance
+	// +kubebuilder:default=NexusInstance
+	Kind string `json:"kind,omitempty"`
+
+	// Name - имя экземпляра.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="URL",type="string",JSONPath=".spec.url"
+//+kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version"
+//+kubebuilder:printcolumn:name="Edition",type="string",JSONPath=".status.edition"
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// NexusInstance - экземпляр Nexus, доступный ресурсам своего пространства имён.
+type NexusInstance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec - параметры подключения. Secret и ConfigMap берутся только из пространства имён экземпляра,
+	// иначе оператор передал бы учётные данные из чужого пространства имён на адрес, выбранный владельцем экземпляра.
+	// +kubebuilder:validation:XValidation:rule="!has(self.credentialsSecretRef.__namespace__)",message="NexusInstance не может ссылаться на Secret в другом пространстве имён: namespace не задаётся"
+	// +kubebuilder:validation:XValidation:rule="!has(self.tls) || !has(self.tls.clientCertSecretRef) || !has(self.tls.clientCertSecretRef.__namespace__)",message="NexusInstance не может ссылаться на Secret в другом пространстве имён: namespace не задаётся"
+	// +kubebuilder:validation:XValidation:rule="!has(self.tls) || !has(self.tls.ca) || (!has(self.tls.ca.configMapRef) || !has(self.tls.ca.configMapRef.__namespace__)) && (!has(self.tls.ca.secretRef) || !has(self.tls.ca.secretRef.__namespace__))",message="NexusInstance не может ссылаться на CA-бандл в другом пространстве имён: namespace не задаётся"
+	// +kubebuilder:validation:XValidation:rule="!has(self.dockerExposure) || !has(self.dockerExposure.__namespace__)",message="NexusInstance публикует Docker-репозитории только в своём пространстве имён: dockerExposure.namespace не задаётся"
+	Spec   NexusInstanceSpec   `json:"spec,omitempty"`
+	Status NexusInstanceStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// NexusInstanceList содержит список NexusInstance.
+type NexusInstanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NexusInstance `json:"items"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="URL",type="string",JSONPath=".spec.url"
+//+kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version"
+//+kubebuilder:printcolumn:name="Edition",type="string",JSONPath=".status.edition"
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ClusterNexusInstance - экземпляр Nexus, доступный ресурсам из любого пространства имён.
+type ClusterNexusInstance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NexusInstanceSpec   `json:"spec,omitempty"`
+	Status NexusInstanceStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterNexusInstanceList содержит список ClusterNexusInstance.
+type ClusterNexusInstanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterNexusInstance `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&NexusInstance{}, &NexusInstanceList{},
+		&ClusterNexusInstance{}, &ClusterNexusInstanceList{},
+	)
+}
@@ -34,6 +34,10 @@ type PrivilegeSpec struct {
 
 	// Конфигурация для типа script
 	Script *ScriptConfig `json:"script,omitempty"`
+
+	// Ссылка на экземпляр Nexus (необязательное).
+	// Если не задана, используется экземпляр по умолчанию из ENV-переменных.
+	InstanceRef *InstanceReference `json:"instanceRef,omitempty"`
 }
 
 // WildcardConfig определяет параметры для wildcard-привилегии
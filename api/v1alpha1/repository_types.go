@@ -2,9 +2,26 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DockerPortAuto - значение httpPort и httpsPort, при котором порт выделяется оператором.
+const DockerPortAuto = "auto"
+
 // RepositorySpec определяет желаемое состояние репозитория Nexus.
+// +kubebuilder:validation:XValidation:rule="!has(self.pypi) || self.type == 'pypi-proxy'",message="настройки pypi применимы только к репозиторию типа pypi-proxy"
+// +kubebuilder:validation:XValidation:rule="has(self.apt) == self.type.startsWith('apt-')",message="настройки apt обязательны для репозиториев apt и неприменимы к остальным"
+// +kubebuilder:validation:XValidation:rule="!has(self.apt) || !has(self.apt.flat) || !self.apt.flat || self.type == 'apt-proxy'",message="apt.flat применим только к репозиторию типа apt-proxy"
+// +kubebuilder:validation:XValidation:rule="has(self.yum) == (self.type == 'yum-hosted')",message="настройки yum обязательны для yum-hosted и неприменимы к остальным"
+// +kubebuilder:validation:XValidation:rule="!has(self.docker) || !has(self.docker.proxy) || self.type == 'docker-proxy'",message="docker.proxy применим только к репозиторию типа docker-proxy"
+// +kubebuilder:validation:XValidation:rule="!has(self.docker) || !has(self.docker.latestPolicy) || !self.docker.latestPolicy || self.type == 'docker-hosted'",message="docker.latestPolicy применим только к репозиторию типа docker-hosted"
+// +kubebuilder:validation:XValidation:rule="!has(self.group) || !has(self.group.writableMember) || self.type == 'docker-group'",message="group.writableMember применим только к репозиторию типа docker-group"
+// +kubebuilder:validation:XValidation:rule="!has(self.nugetProxy) || self.type == 'nuget-proxy'",message="настройки nugetProxy применимы только к репозиторию типа nuget-proxy"
+// +kubebuilder:validation:XValidation:rule="self.type != 'apt-hosted' || has(self.signing)",message="для apt-hosted требуется ключ подписи signing"
+// +kubebuilder:validation:XValidation:rule="!has(self.signing) || self.type in ['apt-hosted', 'yum-proxy', 'yum-group']",message="signing применим только к репозиториям apt-hosted, yum-proxy и yum-group"
+// +kubebuilder:validation:XValidation:rule="!has(self.cleanup) || !self.type.endsWith('-group')",message="cleanup неприменим к групповым репозиториям"
+// +kubebuilder:validation:XValidation:rule="!has(self.routingRule) || !self.type.endsWith('-hosted')",message="routingRule применим только к proxy- и group-репозиториям"
+// +kubebuilder:validation:XValidation:rule="!has(self.httpClient) || !has(self.httpClient.authentication) || !has(self.httpClient.authentication.dockerConfigSecretRef) || self.type == 'docker-proxy'",message="dockerConfigSecretRef применим только к docker-proxy"
 type RepositorySpec struct {
 	// Name - уникальное имя репозитория (неизменяемое).
 	// +kubebuilder:validation:Required
@@ -12,8 +29,9 @@ type RepositorySpec struct {
 	// +kubebuilder:validation:Immutable
 	Name string `json:"name"`
 
-	// Type - тип репозитория (например, maven-hosted, npm-hosted и т.д.) (неизменяемое).
-	// +kubebuilder:validation:Enum=maven-hosted;maven-proxy;maven-group;npm-hosted;npm-proxy;npm-group;docker-hosted;docker-group;docker-proxy;raw-hosted;raw-group;raw-proxy
+	// Type - тип репозитория в виде <формат>-<вид>, например maven-hosted (неизменяемое).
+	// Перечень совпадает с реестром форматов pkg/nexus (nexus.RepositoryTypes).
+	// +kubebuilder:validation:Enum=apt-hosted;apt-proxy;cargo-group;cargo-hosted;cargo-proxy;cocoapods-proxy;conan-hosted;conan-proxy;conda-proxy;docker-group;docker-hosted;docker-proxy;gitlfs-hosted;go-group;go-proxy;helm-hosted;helm-proxy;maven-group;maven-hosted;maven-proxy;npm-group;npm-hosted;npm-proxy;nuget-group;nuget-hosted;nuget-proxy;p2-proxy;pypi-group;pypi-hosted;pypi-proxy;r-group;r-hosted;r-proxy;raw-group;raw-hosted;raw-proxy;rubygems-group;rubygems-hosted;rubygems-proxy;yum-group;yum-hosted;yum-proxy
 	// +kubebuilder:validation:Immutable
 	Type string `json:"type"`
 
@@ -40,6 +58,27 @@ type RepositorySpec struct {
 	// +optional
 	Raw *RawConfig `json:"raw,omitempty"`
 
+	// Pypi - настройки для PyPI (опционально, только для pypi-proxy).
+	// +optional
+	Pypi *PypiConfig `json:"pypi,omitempty"`
+
+	// NugetProxy - настройки для nuget-proxy (опционально).
+	// +optional
+	NugetProxy *NugetProxyConfig `json:"nugetProxy,omitempty"`
+
+	// Apt - настройки для APT (обязательно для apt-hosted и apt-proxy).
+	// +optional
+	Apt *AptConfig `json:"apt,omitempty"`
+
+	// Yum - настройки для Yum (обязательно для yum-hosted).
+	// +optional
+	Yum *YumConfig `json:"yum,omitempty"`
+
+	// Signing - ключ GPG для подписи метаданных (apt-hosted, yum-proxy и yum-group).
+	// Ключ хранится только в Secret и не указывается в спецификации.
+	// +optional
+	Signing *SigningConfig `json:"signing,omitempty"`
+
 	// Proxy - настройки для прокси (опционально).
 	// +optional
 	Proxy *ProxyConfig `json:"proxy,omitempty"`
@@ -50,7 +89,11 @@ type RepositorySpec struct {
 
 	// Cleanup - настройки политики очистки (опционально).
 	// +optional
-	Cleanup *CleanupPolicy `json:"cleanup,omitempty"`
+	Cleanup *CleanupConfig `json:"cleanup,omitempty"`
+
+	// RoutingRule - правило маршрутизации для proxy- и group-репозиториев (опционально).
+	// +optional
+	RoutingRule *RoutingRuleAssignment `json:"routingRule,omitempty"`
 
 	// HttpClient содержит настройки HTTP-клиента.
 	// +optional
@@ -59,6 +102,11 @@ type RepositorySpec struct {
 	// NegativeCache содержит настройки отрицательного кэша.
 	// +optional
 	NegativeCache *NegativeCacheConfig `json:"negativeCache,omitempty"`
+
+	// InstanceRef - ссылка на экземпляр Nexus (опционально).
+	// Если не задана, используется экземпляр по умолчанию из ENV-переменных.
+	// +optional
+	InstanceRef *InstanceReference `json:"instanceRef,omitempty"`
 }
 
 // RepositoryStatus описывает состояние репозитория.
@@ -67,6 +115,35 @@ type RepositoryStatus struct {
 	// +optional
 	Conditions         []metav1.Condition `json:"conditions,omitempty"`
 	ObservedGeneration int64              `json:"observedGeneration,omitempty"`
+
+	// SigningKeyHash - хэш ключа подписи, применённого в Nexus.
+	// Позволяет повторно применить ключ после изменения Secret.
+	// +optional
+	SigningKeyHash string `json:"signingKeyHash,omitempty"`
+
+	// RemoteCredentialsHash - хэш учётных данных удалённого репозитория, применённых в Nexus.
+	// Позволяет повторно применить пароль или токен после изменения Secret.
+	// +optional
+	RemoteCredentialsHash string `json:"remoteCredentialsHash,omitempty"`
+
+	// DockerPorts - порты коннекторов Docker, применённые в Nexus, в том числе выделенные автоматически.
+	// +optional
+	DockerPorts *DockerPortsStatus `json:"dockerPorts,omitempty"`
+
+	// DockerEndpoint - адрес для docker pull и docker push, если включён docker.expose.
+	// +optional
+	DockerEndpoint string `json:"dockerEndpoint,omitempty"`
+}
+
+// DockerPortsStatus - порты коннекторов Docker-репозитория.
+type DockerPortsStatus struct {
+	// HttpPort - HTTP порт коннектора.
+	// +optional
+	HttpPort *int32 `json:"httpPort,omitempty"`
+
+	// HttpsPort - HTTPS порт коннектора.
+	// +optional
+	HttpsPort *int32 `json:"httpsPort,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -105,11 +182,47 @@ type HttpClientConfig struct {
 	// +kubebuilder:default=true
 	AutoBlock bool `json:"autoBlock"`
 
+	// Connection содержит настройки соединения с удалённым репозиторием.
+	// +optional
+	Connection *HttpConnectionConfig `json:"connection,omitempty"`
+
 	// Authentication содержит настройки аутентификации.
 	// +optional
 	Authentication *AuthConfig `json:"authentication,omitempty"`
 }
 
+// HttpConnectionConfig описывает настройки соединения HTTP-клиента.
+// Незаданные числовые параметры Nexus заполняет значениями по умолчанию.
+type HttpConnectionConfig struct {
+	// Timeout - время ожидания ответа удалённого репозитория в секундах.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	Timeout *int32 `json:"timeout,omitempty"`
+
+	// Retries - количество повторных попыток запроса к удалённому репозиторию.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	Retries *int32 `json:"retries,omitempty"`
+
+	// UserAgentSuffix - суффикс, добавляемый к заголовку User-Agent.
+	// +optional
+	UserAgentSuffix string `json:"userAgentSuffix,omitempty"`
+
+	// UseTrustStore включает проверку сертификата удалённого репозитория по хранилищу доверенных сертификатов Nexus.
+	// +optional
+	UseTrustStore bool `json:"useTrustStore,omitempty"`
+
+	// EnableCircularRedirects разрешает циклические перенаправления.
+	// +optional
+	EnableCircularRedirects bool `json:"enableCircularRedirects,omitempty"`
+
+	// EnableCookies разрешает сохранение cookie удалённого репозитория.
+	// +optional
+	EnableCookies bool `json:"enableCookies,omitempty"`
+}
+
 // NegativeCacheConfig описывает настройки отрицательного кэша.
 type NegativeCacheConfig struct {
 	// Enabled включает отрицательное кэширование.
@@ -166,12 +279,21 @@ type NpmConfig struct {
 }
 
 // DockerConfig определяет настройки, специфичные для Docker.
+// +kubebuilder:validation:XValidation:rule="!has(self.expose) || !self.expose || has(self.httpPort) || has(self.httpsPort) || has(self.subdomain)",message="для docker.expose требуется порт коннектора или subdomain"
 type DockerConfig struct {
 	// HttpPort указывает HTTP порт для Docker репозитория.
-	HttpPort *int `json:"httpPort,omitempty"`
+	// Значение auto выделяет свободный порт из диапазона dockerPorts экземпляра Nexus.
+	// +kubebuilder:validation:XIntOrString
+	// +kubebuilder:validation:XValidation:rule="type(self) == string ? self == 'auto' : (self >= 1 && self <= 65535)",message="ожидается номер порта от 1 до 65535 или auto"
+	// +optional
+	HttpPort *intstr.IntOrString `json:"httpPort,omitempty"`
 
 	// HttpsPort указывает HTTPS порт для Docker репозитория.
-	HttpsPort *int `json:"httpsPort,omitempty"`
+	// Значение auto выделяет свободный порт из диапазона dockerPorts экземпляра Nexus.
+	// +kubebuilder:validation:XIntOrString
+	// +kubebuilder:validation:XValidation:rule="type(self) == string ? self == 'auto' : (self >= 1 && self <= 65535)",message="ожидается номер порта от 1 до 65535 или auto"
+	// +optional
+	HttpsPort *intstr.IntOrString `json:"httpsPort,omitempty"`
 
 	// ForceBasicAuth определяет, будет ли применяться базовая аутентификация.
 	ForceBasicAuth bool `json:"forceBasicAuth,omitempty"`
@@ -182,6 +304,45 @@ type DockerConfig struct {
 
 	// Subdomain указывает поддомен для репозитория.
 	Subdomain string `json:"subdomain,omitempty"`
+
+	// PathEnabled включает доступ к репозиторию по пути (path-based routing).
+	// Если не задано, используется значение Nexus.
+	// +optional
+	PathEnabled *bool `json:"pathEnabled,omitempty"`
+
+	// LatestPolicy разрешает перезапись тега latest при политике записи ALLOW_ONCE (только для docker-hosted).
+	// +optional
+	LatestPolicy bool `json:"latestPolicy,omitempty"`
+
+	// Proxy - настройки индекса и внешних слоёв (только для docker-proxy).
+	// +optional
+	Proxy *DockerProxyConfig `json:"proxy,omitempty"`
+
+	// Expose создаёт Service и Ingress или HTTPRoute для коннектора по шаблону dockerExposure экземпляра Nexus.
+	// +optional
+	Expose bool `json:"expose,omitempty"`
+}
+
+// DockerProxyConfig определяет настройки docker-proxy.
+// +kubebuilder:validation:XValidation:rule="self.indexType != 'CUSTOM' || has(self.indexUrl)",message="для indexType CUSTOM требуется indexUrl"
+type DockerProxyConfig struct {
+	// IndexType - тип индекса: REGISTRY (удалённый реестр), HUB (Docker Hub) или CUSTOM (indexUrl).
+	// +kubebuilder:validation:Enum=REGISTRY;HUB;CUSTOM
+	// +kubebuilder:default=REGISTRY
+	IndexType string `json:"indexType,omitempty"`
+
+	// IndexUrl - адрес индекса для indexType CUSTOM.
+	// +kubebuilder:validation:Pattern=`^(http|https)://.+`
+	// +optional
+	IndexUrl string `json:"indexUrl,omitempty"`
+
+	// CacheForeignLayers включает кэширование внешних (foreign) слоёв образов.
+	// +optional
+	CacheForeignLayers bool `json:"cacheForeignLayers,omitempty"`
+
+	// ForeignLayerUrlWhitelist - регулярные выражения адресов внешних слоёв, разрешённых к кэшированию.
+	// +optional
+	ForeignLayerUrlWhitelist []string `json:"foreignLayerUrlWhitelist,omitempty"`
 }
 
 // RawConfig определяет настройки, специфичные для Raw.
@@ -192,6 +353,74 @@ type RawConfig struct {
 	ContentDisposition string `json:"contentDisposition,omitempty"`
 }
 
+// PypiConfig определяет настройки, специфичные для PyPI.
+type PypiConfig struct {
+	// RemoveQuarantined определяет, нужно ли удалять компоненты из карантина.
+	RemoveQuarantined bool `json:"removeQuarantined,omitempty"`
+}
+
+// NugetProxyConfig определяет настройки, специфичные для nuget-proxy.
+type NugetProxyConfig struct {
+	// NugetVersion - версия протокола NuGet удалённого репозитория.
+	// +kubebuilder:validation:Enum=V2;V3
+	// +kubebuilder:default=V3
+	NugetVersion string `json:"nugetVersion,omitempty"`
+
+	// QueryCacheItemMaxAge - время кэширования результатов запросов в секундах.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=3600
+	QueryCacheItemMaxAge int `json:"queryCacheItemMaxAge,omitempty"`
+}
+
+// AptConfig определяет настройки, специфичные для APT.
+type AptConfig struct {
+	// Distribution - дистрибутив, например bookworm.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Distribution string `json:"distribution"`
+
+	// Flat указывает, что удалённый репозиторий имеет плоскую структуру (только для apt-proxy).
+	// +optional
+	Flat bool `json:"flat,omitempty"`
+}
+
+// YumConfig определяет настройки, специфичные для Yum.
+type YumConfig struct {
+	// RepodataDepth - глубина каталога, в котором создаются метаданные repodata.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=5
+	RepodataDepth int `json:"repodataDepth"`
+
+	// DeployPolicy определяет проверку путей при загрузке пакетов.
+	// +kubebuilder:validation:Enum=STRICT;PERMISSIVE
+	// +kubebuilder:default=STRICT
+	DeployPolicy string `json:"deployPolicy,omitempty"`
+}
+
+// SigningConfig описывает ключ GPG для подписи метаданных репозитория.
+type SigningConfig struct {
+	// SecretRef - Secret с закрытым ключом и парольной фразой в пространстве имён ресурса.
+	SecretRef SigningKeySecretReference `json:"secretRef"`
+}
+
+// SigningKeySecretReference - ссылка на Secret с ключом GPG.
+type SigningKeySecretReference struct {
+	// Name - имя Secret.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// KeypairKey - ключ Secret с закрытым ключом GPG в ASCII-armored формате.
+	// +kubebuilder:default=keypair
+	// +optional
+	KeypairKey string `json:"keypairKey,omitempty"`
+
+	// PassphraseKey - ключ Secret с парольной фразой (необязательна, если ключ без пароля).
+	// +kubebuilder:default=passphrase
+	// +optional
+	PassphraseKey string `json:"passphraseKey,omitempty"`
+}
+
 // ProxyConfig определяет настройки прокси для репозитория.
 type ProxyConfig struct {
 	// RemoteUrl указывает удалённый URL для прокси-репозитория.
@@ -206,31 +435,138 @@ type ProxyConfig struct {
 	MetadataMaxAge int `json:"metadataMaxAge,omitempty"`
 }
 
-// AuthConfig описывает параметры HTTP-аутентификации.
+// AuthConfig описывает параметры HTTP-аутентификации. Имя пользователя, пароль и токен задаются значением
+// или ссылкой на Secret в пространстве имён ресурса; для docker-proxy учётные данные можно взять из Secret
+// типа kubernetes.io/dockerconfigjson.
+// +kubebuilder:validation:XValidation:rule="self.type == 'bearerToken' ? has(self.bearerToken) || has(self.bearerTokenSecretRef) : has(self.dockerConfigSecretRef) || (has(self.username) || has(self.usernameSecretRef)) && (has(self.password) || has(self.passwordSecretRef))",message="для bearerToken требуется токен, для username и ntlm - имя пользователя и пароль"
+// +kubebuilder:validation:XValidation:rule="!(has(self.username) && has(self.usernameSecretRef)) && !(has(self.password) && has(self.passwordSecretRef)) && !(has(self.bearerToken) && has(self.bearerTokenSecretRef))",message="значение и ссылка на Secret взаимоисключающие"
+// +kubebuilder:validation:XValidation:rule="self.type != 'ntlm' || has(self.ntlmHost) && has(self.ntlmDomain)",message="для ntlm требуются ntlmHost и ntlmDomain"
+// +kubebuilder:validation:XValidation:rule="self.type == 'ntlm' || !has(self.ntlmHost) && !has(self.ntlmDomain)",message="ntlmHost и ntlmDomain применимы только к ntlm"
+// +kubebuilder:validation:XValidation:rule="self.type == 'bearerToken' || !has(self.bearerToken) && !has(self.bearerTokenSecretRef)",message="bearerToken применим только к типу bearerToken"
+// +kubebuilder:validation:XValidation:rule="self.type != 'bearerToken' || !has(self.username) && !has(self.password) && !has(self.usernameSecretRef) && !has(self.passwordSecretRef) && !has(self.dockerConfigSecretRef)",message="имя пользователя и пароль неприменимы к bearerToken"
+// +kubebuilder:validation:XValidation:rule="!has(self.dockerConfigSecretRef) || self.type == 'username' && !has(self.username) && !has(self.password) && !has(self.usernameSecretRef) && !has(self.passwordSecretRef)",message="dockerConfigSecretRef применим только к типу username и заменяет имя пользователя и пароль"
 type AuthConfig struct {
-	// Type - тип авторизации (Username/BasicAuth или NTLM)
-	// +kubebuilder:validation:Enum=username;ntlm
+	// Type - тип авторизации (username - базовая аутентификация, ntlm или bearerToken).
+	// +kubebuilder:validation:Enum=username;ntlm;bearerToken
 	// +kubebuilder:default=username
 	Type string `json:"type,omitempty"`
 
-	// Username - имя пользователя для базовой аутентификации.
-	// +kubebuilder:validation:Required
-	Username string `json:"username"`
+	// Username - имя пользователя для базовой аутентификации и NTLM.
+	// +optional
+	Username string `json:"username,omitempty"`
 
-	// Password - пароль для базовой аутентификации.
-	// +kubebuilder:validation:Required
-	Password string `json:"password"`
+	// UsernameSecretRef - ключ Secret с именем пользователя.
+	// +optional
+	UsernameSecretRef *SecretKeyReference `json:"usernameSecretRef,omitempty"`
+
+	// Password - пароль для базовой аутентификации и NTLM. Значение хранится в ресурсе открытым текстом,
+	// предпочтительнее passwordSecretRef.
+	// +optional
+	Password string `json:"password,omitempty"`
+
+	// PasswordSecretRef - ключ Secret с паролем.
+	// +optional
+	PasswordSecretRef *SecretKeyReference `json:"passwordSecretRef,omitempty"`
+
+	// NtlmHost - имя хоста для аутентификации NTLM.
+	// +optional
+	NtlmHost string `json:"ntlmHost,omitempty"`
+
+	// NtlmDomain - домен для аутентификации NTLM.
+	// +optional
+	NtlmDomain string `json:"ntlmDomain,omitempty"`
+
+	// BearerToken - токен для аутентификации bearerToken. Значение хранится в ресурсе открытым текстом,
+	// предпочтительнее bearerTokenSecretRef.
+	// +optional
+	BearerToken string `json:"bearerToken,omitempty"`
+
+	// BearerTokenSecretRef - ключ Secret с токеном.
+	// +optional
+	BearerTokenSecretRef *SecretKeyReference `json:"bearerTokenSecretRef,omitempty"`
+
+	// DockerConfigSecretRef - Secret типа kubernetes.io/dockerconfigjson с учётными данными реестра
+	// (только для docker-proxy).
+	// +optional
+	DockerConfigSecretRef *DockerConfigSecretReference `json:"dockerConfigSecretRef,omitempty"`
+
+	// Preemptive включает отправку учётных данных в первом запросе, не дожидаясь ответа 401.
+	// +optional
+	Preemptive bool `json:"preemptive,omitempty"`
+}
+
+// SecretKeyReference - ссылка на ключ Secret в пространстве имён ресурса.
+type SecretKeyReference struct {
+	// Name - имя Secret.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Key - ключ Secret со значением.
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+}
+
+// DockerConfigSecretReference - ссылка на Secret типа kubernetes.io/dockerconfigjson.
+type DockerConfigSecretReference struct {
+	// Name - имя Secret.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Registry - реестр, учётные данные которого используются. По умолчанию - хост proxy.remoteUrl.
+	// +optional
+	Registry string `json:"registry,omitempty"`
 }
 
 // GroupConfig определяет настройки группы для репозитория.
+// +kubebuilder:validation:XValidation:rule="!has(self.writableMember) || self.writableMember in self.memberNames",message="writableMember должен входить в memberNames"
 type GroupConfig struct {
 	// MemberNames содержит список участников группового репозитория.
 	// +kubebuilder:validation:Required
 	MemberNames []string `json:"memberNames"`
+
+	// WritableMember - участник группы, в который выполняется публикация через групповой репозиторий
+	// (только для docker-group, участник должен быть docker-hosted).
+	// +optional
+	WritableMember string `json:"writableMember,omitempty"`
 }
 
-// CleanupPolicy определяет политики очистки для репозитория.
-type CleanupPolicy struct {
+// CleanupConfig определяет политики очистки для репозитория.
+// Политики задаются именами в Nexus или ссылками на ресурсы CleanupPolicy; ссылки
+// заменяются именами политик при обработке репозитория.
+type CleanupConfig struct {
 	// PolicyNames содержит список политик очистки, применяемых к репозиторию.
+	// +optional
 	PolicyNames []string `json:"policyNames,omitempty"`
+
+	// PolicyRefs - ссылки на ресурсы CleanupPolicy в пространстве имён репозитория.
+	// +optional
+	PolicyRefs []CleanupPolicyReference `json:"policyRefs,omitempty"`
+}
+
+// RoutingRuleAssignment определяет правило маршрутизации репозитория: по имени в Nexus или
+// ссылкой на ресурс RoutingRule. Ссылка заменяется именем правила при обработке репозитория.
+// +kubebuilder:validation:XValidation:rule="has(self.name) != has(self.ref)",message="требуется ровно одно из полей name и ref"
+type RoutingRuleAssignment struct {
+	// Name - имя правила маршрутизации в Nexus.
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Ref - ссылка на ресурс RoutingRule в пространстве имён репозитория.
+	// +optional
+	Ref *RoutingRuleReference `json:"ref,omitempty"`
+}
+
+// RoutingRuleReference - ссылка на ресурс RoutingRule.
+type RoutingRuleReference struct {
+	// Name - имя ресурса RoutingRule.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// CleanupPolicyReference - ссылка на ресурс CleanupPolicy.
+type CleanupPolicyReference struct {
+	// Name - имя ресурса CleanupPolicy.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
 }
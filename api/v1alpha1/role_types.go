@@ -30,6 +30,10 @@ type RoleSpec struct {
 
 	// Конфигурация внешних источников ролей (опционально)
 	Source *RoleSource `json:"source,omitempty"`
+
+	// Ссылка на экземпляр Nexus (опционально).
+	// Если не задана, используется экземпляр по умолчанию из ENV-переменных.
+	InstanceRef *InstanceReference `json:"instanceRef,omitempty"`
 }
 
 // RoleSource определяет внешний источник для роли
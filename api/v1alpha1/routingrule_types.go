@@ -0,0 +1,69 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RoutingRuleSpec определяет желаемое состояние правила маршрутизации Nexus.
+type RoutingRuleSpec struct {
+	// Name - название правила маршрутизации в Nexus (неизменяемое).
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9\-]{1}[a-zA-Z0-9_\-\.]*$`
+	// +kubebuilder:validation:Immutable
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="name неизменяемое"
+	Name string `json:"name"`
+
+	// Description - описание правила.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Mode - режим правила: BLOCK запрещает запросы, путь которых соответствует одному из выражений,
+	// ALLOW разрешает только такие запросы.
+	// +kubebuilder:validation:Enum=ALLOW;BLOCK
+	Mode string `json:"mode"`
+
+	// Matchers - регулярные выражения для пути запроса, например ^/com/example/.*.
+	// +kubebuilder:validation:MinItems=1
+	Matchers []string `json:"matchers"`
+
+	// InstanceRef - ссылка на экземпляр Nexus.
+	// Если не задана, используется экземпляр по умолчанию из ENV-переменных.
+	// +optional
+	InstanceRef *InstanceReference `json:"instanceRef,omitempty"`
+}
+
+// RoutingRuleStatus описывает состояние правила маршрутизации.
+type RoutingRuleStatus struct {
+	// Conditions содержит список условий, описывающих состояние ресурса.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Name",type="string",JSONPath=".spec.name"
+//+kubebuilder:printcolumn:name="Mode",type="string",JSONPath=".spec.mode"
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// RoutingRule - правило маршрутизации Nexus, ограничивающее запросы proxy- и group-репозиториев.
+type RoutingRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RoutingRuleSpec   `json:"spec,omitempty"`
+	Status RoutingRuleStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RoutingRuleList содержит список RoutingRule.
+type RoutingRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RoutingRule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RoutingRule{}, &RoutingRuleList{})
+}
@@ -23,6 +23,7 @@ package v1alpha1
 import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -45,9 +46,44 @@ func (in *ApplicationConfig) DeepCopy() *ApplicationConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AptConfig) DeepCopyInto(out *AptConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AptConfig.
+func (in *AptConfig) DeepCopy() *AptConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AptConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthConfig) DeepCopyInto(out *AuthConfig) {
 	*out = *in
+	if in.UsernameSecretRef != nil {
+		in, out := &in.UsernameSecretRef, &out.UsernameSecretRef
+		*out = new(SecretKeyReference)
+		**out = **in
+	}
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(SecretKeyReference)
+		**out = **in
+	}
+	if in.BearerTokenSecretRef != nil {
+		in, out := &in.BearerTokenSecretRef, &out.BearerTokenSecretRef
+		*out = new(SecretKeyReference)
+		**out = **in
+	}
+	if in.DockerConfigSecretRef != nil {
+		in, out := &in.DockerConfigSecretRef, &out.DockerConfigSecretRef
+		*out = new(DockerConfigSecretReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthConfig.
@@ -61,46 +97,228 @@ func (in *AuthConfig) DeepCopy() *AuthConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CleanupPolicy) DeepCopyInto(out *CleanupPolicy) {
+func (in *BlobStore) DeepCopyInto(out *BlobStore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlobStore.
+func (in *BlobStore) DeepCopy() *BlobStore {
+	if in == nil {
+		return nil
+	}
+	out := new(BlobStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BlobStore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlobStoreList) DeepCopyInto(out *BlobStoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BlobStore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlobStoreList.
+func (in *BlobStoreList) DeepCopy() *BlobStoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(BlobStoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BlobStoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlobStoreSoftQuota) DeepCopyInto(out *BlobStoreSoftQuota) {
+	*out = *in
+	out.Limit = in.Limit.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlobStoreSoftQuota.
+func (in *BlobStoreSoftQuota) DeepCopy() *BlobStoreSoftQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(BlobStoreSoftQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlobStoreSpec) DeepCopyInto(out *BlobStoreSpec) {
+	*out = *in
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(FileBlobStoreConfig)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3BlobStoreConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SoftQuota != nil {
+		in, out := &in.SoftQuota, &out.SoftQuota
+		*out = new(BlobStoreSoftQuota)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InstanceRef != nil {
+		in, out := &in.InstanceRef, &out.InstanceRef
+		*out = new(InstanceReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlobStoreSpec.
+func (in *BlobStoreSpec) DeepCopy() *BlobStoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BlobStoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlobStoreStatus) DeepCopyInto(out *BlobStoreStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlobStoreStatus.
+func (in *BlobStoreStatus) DeepCopy() *BlobStoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BlobStoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CABundleSource) DeepCopyInto(out *CABundleSource) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(KeyReference)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(KeyReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CABundleSource.
+func (in *CABundleSource) DeepCopy() *CABundleSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CABundleSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CircuitBreakerOptions) DeepCopyInto(out *CircuitBreakerOptions) {
+	*out = *in
+	if in.OpenTimeout != nil {
+		in, out := &in.OpenTimeout, &out.OpenTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CircuitBreakerOptions.
+func (in *CircuitBreakerOptions) DeepCopy() *CircuitBreakerOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(CircuitBreakerOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CleanupConfig) DeepCopyInto(out *CleanupConfig) {
 	*out = *in
 	if in.PolicyNames != nil {
 		in, out := &in.PolicyNames, &out.PolicyNames
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PolicyRefs != nil {
+		in, out := &in.PolicyRefs, &out.PolicyRefs
+		*out = make([]CleanupPolicyReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupPolicy.
-func (in *CleanupPolicy) DeepCopy() *CleanupPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupConfig.
+func (in *CleanupConfig) DeepCopy() *CleanupConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(CleanupPolicy)
+	out := new(CleanupConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ContentSelector) DeepCopyInto(out *ContentSelector) {
+func (in *CleanupPolicy) DeepCopyInto(out *CleanupPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContentSelector.
-func (in *ContentSelector) DeepCopy() *ContentSelector {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupPolicy.
+func (in *CleanupPolicy) DeepCopy() *CleanupPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(ContentSelector)
+	out := new(CleanupPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ContentSelector) DeepCopyObject() runtime.Object {
+func (in *CleanupPolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -108,31 +326,56 @@ func (in *ContentSelector) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ContentSelectorList) DeepCopyInto(out *ContentSelectorList) {
+func (in *CleanupPolicyCriteria) DeepCopyInto(out *CleanupPolicyCriteria) {
+	*out = *in
+	if in.LastDownloaded != nil {
+		in, out := &in.LastDownloaded, &out.LastDownloaded
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LastBlobUpdated != nil {
+		in, out := &in.LastBlobUpdated, &out.LastBlobUpdated
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupPolicyCriteria.
+func (in *CleanupPolicyCriteria) DeepCopy() *CleanupPolicyCriteria {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupPolicyCriteria)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CleanupPolicyList) DeepCopyInto(out *CleanupPolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ContentSelector, len(*in))
+		*out = make([]CleanupPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContentSelectorList.
-func (in *ContentSelectorList) DeepCopy() *ContentSelectorList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupPolicyList.
+func (in *CleanupPolicyList) DeepCopy() *CleanupPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(ContentSelectorList)
+	out := new(CleanupPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ContentSelectorList) DeepCopyObject() runtime.Object {
+func (in *CleanupPolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -140,22 +383,43 @@ func (in *ContentSelectorList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ContentSelectorSpec) DeepCopyInto(out *ContentSelectorSpec) {
+func (in *CleanupPolicyReference) DeepCopyInto(out *CleanupPolicyReference) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContentSelectorSpec.
-func (in *ContentSelectorSpec) DeepCopy() *ContentSelectorSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupPolicyReference.
+func (in *CleanupPolicyReference) DeepCopy() *CleanupPolicyReference {
 	if in == nil {
 		return nil
 	}
-	out := new(ContentSelectorSpec)
+	out := new(CleanupPolicyReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ContentSelectorStatus) DeepCopyInto(out *ContentSelectorStatus) {
+func (in *CleanupPolicySpec) DeepCopyInto(out *CleanupPolicySpec) {
+	*out = *in
+	in.Criteria.DeepCopyInto(&out.Criteria)
+	if in.InstanceRef != nil {
+		in, out := &in.InstanceRef, &out.InstanceRef
+		*out = new(InstanceReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupPolicySpec.
+func (in *CleanupPolicySpec) DeepCopy() *CleanupPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CleanupPolicyStatus) DeepCopyInto(out *CleanupPolicyStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -166,122 +430,732 @@ func (in *ContentSelectorStatus) DeepCopyInto(out *ContentSelectorStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContentSelectorStatus.
-func (in *ContentSelectorStatus) DeepCopy() *ContentSelectorStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupPolicyStatus.
+func (in *CleanupPolicyStatus) DeepCopy() *CleanupPolicyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ContentSelectorStatus)
+	out := new(CleanupPolicyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DockerConfig) DeepCopyInto(out *DockerConfig) {
+func (in *ClientOptions) DeepCopyInto(out *ClientOptions) {
 	*out = *in
-	if in.HttpPort != nil {
-		in, out := &in.HttpPort, &out.HttpPort
-		*out = new(int)
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
 		**out = **in
 	}
-	if in.HttpsPort != nil {
-		in, out := &in.HttpsPort, &out.HttpsPort
-		*out = new(int)
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimitOptions)
 		**out = **in
 	}
+	if in.CircuitBreaker != nil {
+		in, out := &in.CircuitBreaker, &out.CircuitBreaker
+		*out = new(CircuitBreakerOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerConfig.
-func (in *DockerConfig) DeepCopy() *DockerConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientOptions.
+func (in *ClientOptions) DeepCopy() *ClientOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(DockerConfig)
+	out := new(ClientOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GroupConfig) DeepCopyInto(out *GroupConfig) {
+func (in *ClusterNexusInstance) DeepCopyInto(out *ClusterNexusInstance) {
 	*out = *in
-	if in.MemberNames != nil {
-		in, out := &in.MemberNames, &out.MemberNames
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNexusInstance.
+func (in *ClusterNexusInstance) DeepCopy() *ClusterNexusInstance {
+	if in == nil {
+		return nil
 	}
+	out := new(ClusterNexusInstance)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupConfig.
-func (in *GroupConfig) DeepCopy() *GroupConfig {
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterNexusInstance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNexusInstanceList) DeepCopyInto(out *ClusterNexusInstanceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterNexusInstance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNexusInstanceList.
+func (in *ClusterNexusInstanceList) DeepCopy() *ClusterNexusInstanceList {
 	if in == nil {
 		return nil
 	}
-	out := new(GroupConfig)
+	out := new(ClusterNexusInstanceList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterNexusInstanceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HttpClientConfig) DeepCopyInto(out *HttpClientConfig) {
+func (in *ContentSelector) DeepCopyInto(out *ContentSelector) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContentSelector.
+func (in *ContentSelector) DeepCopy() *ContentSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ContentSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ContentSelector) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContentSelectorList) DeepCopyInto(out *ContentSelectorList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ContentSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContentSelectorList.
+func (in *ContentSelectorList) DeepCopy() *ContentSelectorList {
+	if in == nil {
+		return nil
+	}
+	out := new(ContentSelectorList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ContentSelectorList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContentSelectorSpec) DeepCopyInto(out *ContentSelectorSpec) {
+	*out = *in
+	if in.InstanceRef != nil {
+		in, out := &in.InstanceRef, &out.InstanceRef
+		*out = new(InstanceReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContentSelectorSpec.
+func (in *ContentSelectorSpec) DeepCopy() *ContentSelectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ContentSelectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContentSelectorStatus) DeepCopyInto(out *ContentSelectorStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContentSelectorStatus.
+func (in *ContentSelectorStatus) DeepCopy() *ContentSelectorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ContentSelectorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialsSecretReference) DeepCopyInto(out *CredentialsSecretReference) {
+	*out = *in
+	out.ObjectReference = in.ObjectReference
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsSecretReference.
+func (in *CredentialsSecretReference) DeepCopy() *CredentialsSecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialsSecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DockerConfig) DeepCopyInto(out *DockerConfig) {
+	*out = *in
+	if in.HttpPort != nil {
+		in, out := &in.HttpPort, &out.HttpPort
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.HttpsPort != nil {
+		in, out := &in.HttpsPort, &out.HttpsPort
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.PathEnabled != nil {
+		in, out := &in.PathEnabled, &out.PathEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(DockerProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerConfig.
+func (in *DockerConfig) DeepCopy() *DockerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DockerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DockerConfigSecretReference) DeepCopyInto(out *DockerConfigSecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerConfigSecretReference.
+func (in *DockerConfigSecretReference) DeepCopy() *DockerConfigSecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(DockerConfigSecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DockerExposureTemplate) DeepCopyInto(out *DockerExposureTemplate) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(DockerIngressTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTPRoute != nil {
+		in, out := &in.HTTPRoute, &out.HTTPRoute
+		*out = new(DockerHTTPRouteTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerExposureTemplate.
+func (in *DockerExposureTemplate) DeepCopy() *DockerExposureTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(DockerExposureTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DockerHTTPRouteTemplate) DeepCopyInto(out *DockerHTTPRouteTemplate) {
+	*out = *in
+	if in.ParentRefs != nil {
+		in, out := &in.ParentRefs, &out.ParentRefs
+		*out = make([]GatewayParentReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerHTTPRouteTemplate.
+func (in *DockerHTTPRouteTemplate) DeepCopy() *DockerHTTPRouteTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(DockerHTTPRouteTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DockerIngressTemplate) DeepCopyInto(out *DockerIngressTemplate) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerIngressTemplate.
+func (in *DockerIngressTemplate) DeepCopy() *DockerIngressTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(DockerIngressTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DockerPortsStatus) DeepCopyInto(out *DockerPortsStatus) {
+	*out = *in
+	if in.HttpPort != nil {
+		in, out := &in.HttpPort, &out.HttpPort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HttpsPort != nil {
+		in, out := &in.HttpsPort, &out.HttpsPort
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerPortsStatus.
+func (in *DockerPortsStatus) DeepCopy() *DockerPortsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DockerPortsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DockerProxyConfig) DeepCopyInto(out *DockerProxyConfig) {
+	*out = *in
+	if in.ForeignLayerUrlWhitelist != nil {
+		in, out := &in.ForeignLayerUrlWhitelist, &out.ForeignLayerUrlWhitelist
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerProxyConfig.
+func (in *DockerProxyConfig) DeepCopy() *DockerProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DockerProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileBlobStoreConfig) DeepCopyInto(out *FileBlobStoreConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileBlobStoreConfig.
+func (in *FileBlobStoreConfig) DeepCopy() *FileBlobStoreConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FileBlobStoreConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayParentReference) DeepCopyInto(out *GatewayParentReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParentReference.
+func (in *GatewayParentReference) DeepCopy() *GatewayParentReference {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayParentReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupConfig) DeepCopyInto(out *GroupConfig) {
+	*out = *in
+	if in.MemberNames != nil {
+		in, out := &in.MemberNames, &out.MemberNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupConfig.
+func (in *GroupConfig) DeepCopy() *GroupConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HttpClientConfig) DeepCopyInto(out *HttpClientConfig) {
+	*out = *in
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(HttpConnectionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(AuthConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HttpClientConfig.
+func (in *HttpClientConfig) DeepCopy() *HttpClientConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HttpClientConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HttpConnectionConfig) DeepCopyInto(out *HttpConnectionConfig) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HttpConnectionConfig.
+func (in *HttpConnectionConfig) DeepCopy() *HttpConnectionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HttpConnectionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceReference) DeepCopyInto(out *InstanceReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceReference.
+func (in *InstanceReference) DeepCopy() *InstanceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyReference) DeepCopyInto(out *KeyReference) {
+	*out = *in
+	out.ObjectReference = in.ObjectReference
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyReference.
+func (in *KeyReference) DeepCopy() *KeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MavenConfig) DeepCopyInto(out *MavenConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MavenConfig.
+func (in *MavenConfig) DeepCopy() *MavenConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MavenConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NegativeCacheConfig) DeepCopyInto(out *NegativeCacheConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NegativeCacheConfig.
+func (in *NegativeCacheConfig) DeepCopy() *NegativeCacheConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NegativeCacheConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusInstance) DeepCopyInto(out *NexusInstance) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusInstance.
+func (in *NexusInstance) DeepCopy() *NexusInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NexusInstance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusInstanceList) DeepCopyInto(out *NexusInstanceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NexusInstance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusInstanceList.
+func (in *NexusInstanceList) DeepCopy() *NexusInstanceList {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusInstanceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NexusInstanceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusInstanceSpec) DeepCopyInto(out *NexusInstanceSpec) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.ClientOptions != nil {
+		in, out := &in.ClientOptions, &out.ClientOptions
+		*out = new(ClientOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DockerPorts != nil {
+		in, out := &in.DockerPorts, &out.DockerPorts
+		*out = new(PortRange)
+		**out = **in
+	}
+	if in.DockerExposure != nil {
+		in, out := &in.DockerExposure, &out.DockerExposure
+		*out = new(DockerExposureTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusInstanceSpec.
+func (in *NexusInstanceSpec) DeepCopy() *NexusInstanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusInstanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusInstanceStatus) DeepCopyInto(out *NexusInstanceStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusInstanceStatus.
+func (in *NexusInstanceStatus) DeepCopy() *NexusInstanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusInstanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NpmConfig) DeepCopyInto(out *NpmConfig) {
 	*out = *in
-	if in.Authentication != nil {
-		in, out := &in.Authentication, &out.Authentication
-		*out = new(AuthConfig)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HttpClientConfig.
-func (in *HttpClientConfig) DeepCopy() *HttpClientConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NpmConfig.
+func (in *NpmConfig) DeepCopy() *NpmConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(HttpClientConfig)
+	out := new(NpmConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MavenConfig) DeepCopyInto(out *MavenConfig) {
+func (in *NugetProxyConfig) DeepCopyInto(out *NugetProxyConfig) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MavenConfig.
-func (in *MavenConfig) DeepCopy() *MavenConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NugetProxyConfig.
+func (in *NugetProxyConfig) DeepCopy() *NugetProxyConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(MavenConfig)
+	out := new(NugetProxyConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NegativeCacheConfig) DeepCopyInto(out *NegativeCacheConfig) {
+func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NegativeCacheConfig.
-func (in *NegativeCacheConfig) DeepCopy() *NegativeCacheConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectReference.
+func (in *ObjectReference) DeepCopy() *ObjectReference {
 	if in == nil {
 		return nil
 	}
-	out := new(NegativeCacheConfig)
+	out := new(ObjectReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NpmConfig) DeepCopyInto(out *NpmConfig) {
+func (in *PortRange) DeepCopyInto(out *PortRange) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NpmConfig.
-func (in *NpmConfig) DeepCopy() *NpmConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortRange.
+func (in *PortRange) DeepCopy() *PortRange {
 	if in == nil {
 		return nil
 	}
-	out := new(NpmConfig)
+	out := new(PortRange)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -378,6 +1252,11 @@ func (in *PrivilegeSpec) DeepCopyInto(out *PrivilegeSpec) {
 		*out = new(ScriptConfig)
 		**out = **in
 	}
+	if in.InstanceRef != nil {
+		in, out := &in.InstanceRef, &out.InstanceRef
+		*out = new(InstanceReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivilegeSpec.
@@ -427,6 +1306,36 @@ func (in *ProxyConfig) DeepCopy() *ProxyConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PypiConfig) DeepCopyInto(out *PypiConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PypiConfig.
+func (in *PypiConfig) DeepCopy() *PypiConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PypiConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitOptions) DeepCopyInto(out *RateLimitOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitOptions.
+func (in *RateLimitOptions) DeepCopy() *RateLimitOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RawConfig) DeepCopyInto(out *RawConfig) {
 	*out = *in
@@ -560,6 +1469,31 @@ func (in *RepositorySpec) DeepCopyInto(out *RepositorySpec) {
 		*out = new(RawConfig)
 		**out = **in
 	}
+	if in.Pypi != nil {
+		in, out := &in.Pypi, &out.Pypi
+		*out = new(PypiConfig)
+		**out = **in
+	}
+	if in.NugetProxy != nil {
+		in, out := &in.NugetProxy, &out.NugetProxy
+		*out = new(NugetProxyConfig)
+		**out = **in
+	}
+	if in.Apt != nil {
+		in, out := &in.Apt, &out.Apt
+		*out = new(AptConfig)
+		**out = **in
+	}
+	if in.Yum != nil {
+		in, out := &in.Yum, &out.Yum
+		*out = new(YumConfig)
+		**out = **in
+	}
+	if in.Signing != nil {
+		in, out := &in.Signing, &out.Signing
+		*out = new(SigningConfig)
+		**out = **in
+	}
 	if in.Proxy != nil {
 		in, out := &in.Proxy, &out.Proxy
 		*out = new(ProxyConfig)
@@ -572,7 +1506,12 @@ func (in *RepositorySpec) DeepCopyInto(out *RepositorySpec) {
 	}
 	if in.Cleanup != nil {
 		in, out := &in.Cleanup, &out.Cleanup
-		*out = new(CleanupPolicy)
+		*out = new(CleanupConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RoutingRule != nil {
+		in, out := &in.RoutingRule, &out.RoutingRule
+		*out = new(RoutingRuleAssignment)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.HttpClient != nil {
@@ -585,6 +1524,11 @@ func (in *RepositorySpec) DeepCopyInto(out *RepositorySpec) {
 		*out = new(NegativeCacheConfig)
 		**out = **in
 	}
+	if in.InstanceRef != nil {
+		in, out := &in.InstanceRef, &out.InstanceRef
+		*out = new(InstanceReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositorySpec.
@@ -607,6 +1551,11 @@ func (in *RepositoryStatus) DeepCopyInto(out *RepositoryStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DockerPorts != nil {
+		in, out := &in.DockerPorts, &out.DockerPorts
+		*out = new(DockerPortsStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryStatus.
@@ -731,6 +1680,11 @@ func (in *RoleSpec) DeepCopyInto(out *RoleSpec) {
 		*out = new(RoleSource)
 		**out = **in
 	}
+	if in.InstanceRef != nil {
+		in, out := &in.InstanceRef, &out.InstanceRef
+		*out = new(InstanceReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleSpec.
@@ -765,6 +1719,187 @@ func (in *RoleStatus) DeepCopy() *RoleStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutingRule) DeepCopyInto(out *RoutingRule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutingRule.
+func (in *RoutingRule) DeepCopy() *RoutingRule {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutingRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RoutingRule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutingRuleAssignment) DeepCopyInto(out *RoutingRuleAssignment) {
+	*out = *in
+	if in.Ref != nil {
+		in, out := &in.Ref, &out.Ref
+		*out = new(RoutingRuleReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutingRuleAssignment.
+func (in *RoutingRuleAssignment) DeepCopy() *RoutingRuleAssignment {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutingRuleAssignment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutingRuleList) DeepCopyInto(out *RoutingRuleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RoutingRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutingRuleList.
+func (in *RoutingRuleList) DeepCopy() *RoutingRuleList {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutingRuleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RoutingRuleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutingRuleReference) DeepCopyInto(out *RoutingRuleReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutingRuleReference.
+func (in *RoutingRuleReference) DeepCopy() *RoutingRuleReference {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutingRuleReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutingRuleSpec) DeepCopyInto(out *RoutingRuleSpec) {
+	*out = *in
+	if in.Matchers != nil {
+		in, out := &in.Matchers, &out.Matchers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InstanceRef != nil {
+		in, out := &in.InstanceRef, &out.InstanceRef
+		*out = new(InstanceReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutingRuleSpec.
+func (in *RoutingRuleSpec) DeepCopy() *RoutingRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutingRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutingRuleStatus) DeepCopyInto(out *RoutingRuleStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutingRuleStatus.
+func (in *RoutingRuleStatus) DeepCopy() *RoutingRuleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutingRuleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3BlobStoreConfig) DeepCopyInto(out *S3BlobStoreConfig) {
+	*out = *in
+	if in.Expiration != nil {
+		in, out := &in.Expiration, &out.Expiration
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(S3CredentialsSecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BlobStoreConfig.
+func (in *S3BlobStoreConfig) DeepCopy() *S3BlobStoreConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(S3BlobStoreConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3CredentialsSecretReference) DeepCopyInto(out *S3CredentialsSecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3CredentialsSecretReference.
+func (in *S3CredentialsSecretReference) DeepCopy() *S3CredentialsSecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(S3CredentialsSecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScriptConfig) DeepCopyInto(out *ScriptConfig) {
 	*out = *in
@@ -780,6 +1915,52 @@ func (in *ScriptConfig) DeepCopy() *ScriptConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyReference) DeepCopyInto(out *SecretKeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyReference.
+func (in *SecretKeyReference) DeepCopy() *SecretKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SigningConfig) DeepCopyInto(out *SigningConfig) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SigningConfig.
+func (in *SigningConfig) DeepCopy() *SigningConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SigningConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SigningKeySecretReference) DeepCopyInto(out *SigningKeySecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SigningKeySecretReference.
+func (in *SigningKeySecretReference) DeepCopy() *SigningKeySecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SigningKeySecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageConfig) DeepCopyInto(out *StorageConfig) {
 	*out = *in
@@ -795,6 +1976,31 @@ func (in *StorageConfig) DeepCopy() *StorageConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
+	*out = *in
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(CABundleSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientCertSecretRef != nil {
+		in, out := &in.ClientCertSecretRef, &out.ClientCertSecretRef
+		*out = new(ObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSConfig.
+func (in *TLSConfig) DeepCopy() *TLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WildcardConfig) DeepCopyInto(out *WildcardConfig) {
 	*out = *in
@@ -809,3 +2015,18 @@ func (in *WildcardConfig) DeepCopy() *WildcardConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *YumConfig) DeepCopyInto(out *YumConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YumConfig.
+func (in *YumConfig) DeepCopy() *YumConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(YumConfig)
+	in.DeepCopyInto(out)
+	return out
+}
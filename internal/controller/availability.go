@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/mkostelcev/nexus-operator/pkg/nexus"
+)
+
+// errDependencyNotReady - ресурс, от которого зависит обрабатываемый, отсутствует или ещё не синхронизирован.
+// Обработка откладывается до его готовности.
+var errDependencyNotReady = errors.New("зависимость не готова")
+
+// failureReason возвращает причину условия Ready для ошибки синхронизации.
+func failureReason(cause error) string {
+	switch {
+	case errors.Is(cause, nexus.ErrNexusUnavailable):
+		return nexusUnavailableReason
+	case errors.Is(cause, nexus.ErrUnsupported):
+		return unsupportedReason
+	case errors.Is(cause, errDockerPortConflict):
+		return portConflictReason
+	case errors.Is(cause, errDependencyNotReady):
+		return dependencyNotReadyReason
+	case errors.Is(cause, errDockerExposureConflict):
+		return conflictReason
+	case errors.Is(cause, errInlineCredentials):
+		return policyViolationReason
+	case errors.Is(cause, nexus.ErrRoutingRuleInUse):
+		return inUseReason
+	default:
+		return errorReason
+	}
+}
+
+// failureRequeueDelay возвращает задержку повторной обработки после ошибки.
+// Пока выключатель разомкнут, ресурс откладывается до пробной попытки с разбросом,
+// чтобы после восстановления Nexus ресурсы обрабатывались постепенно, а не все сразу.
+func failureRequeueDelay(cause error, fallback time.Duration) time.Duration {
+	var unavailable *nexus.UnavailableError
+	if !errors.As(cause, &unavailable) {
+		return fallback
+	}
+
+	delay := unavailable.RetryAfter
+	if delay < time.Second {
+		delay = time.Second
+	}
+	return delay + time.Duration(rand.Int63n(int64(fallback)+1))
+}
@@ -0,0 +1,268 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	nexusv1alpha1 "github.com/mkostelcev/nexus-operator/api/v1alpha1"
+	"github.com/mkostelcev/nexus-operator/pkg/nexus"
+	"github.com/mkostelcev/nexus-operator/pkg/utils"
+)
+
+const (
+	blobStoreFinalizer    = "finalizer.nexus.operators.dev.kostoed.ru"
+	blobStoreRequeueDelay = 30 * time.Second
+
+	// defaultAccessKeyIDKey и defaultSecretAccessKeyKey - ключи Secret с ключом доступа S3 по умолчанию.
+	defaultAccessKeyIDKey     = "accessKeyId"
+	defaultSecretAccessKeyKey = "secretAccessKey"
+)
+
+type BlobStoreReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+	// Nexus возвращает API Nexus для обрабатываемого ресурса.
+	Nexus APIProvider
+	// Instances используется для отслеживания изменений экземпляров Nexus.
+	Instances *InstanceResolver
+}
+
+//+kubebuilder:rbac:groups=nexus.operators.dev.kostoed.ru,resources=blobstores,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=nexus.operators.dev.kostoed.ru,resources=blobstores/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=nexus.operators.dev.kostoed.ru,resources=blobstores/finalizers,verbs=update
+
+func (r *BlobStoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("blobstore", req.NamespacedName)
+	log.Info("Начало обработки хранилища blob-объектов")
+
+	var storeCR nexusv1alpha1.BlobStore
+	if err := r.Get(ctx, req.NamespacedName, &storeCR); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("ошибка получения хранилища: %w", err)
+	}
+
+	if !storeCR.ObjectMeta.DeletionTimestamp.IsZero() {
+		return r.finalizeBlobStore(ctx, &storeCR, log)
+	}
+
+	if !utils.ContainsString(storeCR.Finalizers, blobStoreFinalizer) {
+		storeCR.Finalizers = append(storeCR.Finalizers, blobStoreFinalizer)
+		if err := r.Update(ctx, &storeCR); err != nil {
+			return ctrl.Result{}, fmt.Errorf("ошибка при добавлении финализатора: %w", err)
+		}
+	}
+
+	return r.syncBlobStore(ctx, &storeCR, log)
+}
+
+func (r *BlobStoreReconciler) syncBlobStore(
+	ctx context.Context,
+	store *nexusv1alpha1.BlobStore,
+	log logr.Logger,
+) (ctrl.Result, error) {
+	nexusClient, err := r.Nexus.APIFor(ctx, store.Namespace, store.Spec.InstanceRef)
+	if err != nil {
+		return r.updateStatus(ctx, store, false, fmt.Errorf("ошибка подключения к Nexus: %w", err))
+	}
+	if err := nexusClient.Available(); err != nil {
+		log.Info("Обращения к Nexus приостановлены", "reason", err.Error())
+		return r.updateStatus(ctx, store, false, err)
+	}
+
+	credentials, err := r.s3Credentials(ctx, store)
+	if err != nil {
+		return r.updateStatus(ctx, store, false, fmt.Errorf("ошибка получения ключа доступа S3: %w", err))
+	}
+
+	desiredConfig, err := nexus.BuildBlobStoreConfig(store.Spec, credentials)
+	if err != nil {
+		return r.updateStatus(ctx, store, false, fmt.Errorf("ошибка формирования конфигурации: %w", err))
+	}
+
+	currentConfig, err := nexusClient.GetBlobStore(ctx, store.Spec.Type, store.Spec.Name)
+	if errors.Is(err, nexus.ErrBlobStoreNotFound) {
+		if err := nexusClient.CreateBlobStore(ctx, desiredConfig); err != nil {
+			return r.updateStatus(ctx, store, false, fmt.Errorf("ошибка создания хранилища: %w", err))
+		}
+		log.Info("Хранилище успешно создано")
+		return r.applied(ctx, store, credentials.Hash())
+	}
+	if err != nil {
+		return r.updateStatus(ctx, store, false, fmt.Errorf("ошибка получения хранилища: %w", err))
+	}
+
+	// Nexus не возвращает секретный ключ S3, поэтому его изменение определяется по хэшу.
+	diff := nexus.BlobStoreDiff(desiredConfig, currentConfig)
+	if diff != "" || credentials.Hash() != store.Status.CredentialsHash {
+		log.Info("Обнаружены изменения хранилища", "diff", diff)
+		if err := nexusClient.UpdateBlobStore(ctx, store.Spec.Name, desiredConfig); err != nil {
+			return r.updateStatus(ctx, store, false, fmt.Errorf("ошибка обновления хранилища: %w", err))
+		}
+		log.Info("Хранилище успешно обновлено")
+		return r.applied(ctx, store, credentials.Hash())
+	}
+
+	return r.updateStatus(ctx, store, true, nil)
+}
+
+// applied сохраняет хэш применённого ключа доступа и отмечает хранилище готовым.
+func (r *BlobStoreReconciler) applied(
+	ctx context.Context,
+	store *nexusv1alpha1.BlobStore,
+	credentialsHash string,
+) (ctrl.Result, error) {
+	store.Status.CredentialsHash = credentialsHash
+	return r.updateStatus(ctx, store, true, nil)
+}
+
+// s3Credentials читает ключ доступа S3 из Secret. Nil, если ссылка на Secret не задана.
+func (r *BlobStoreReconciler) s3Credentials(
+	ctx context.Context,
+	store *nexusv1alpha1.BlobStore,
+) (*nexus.S3Credentials, error) {
+	if store.Spec.S3 == nil || store.Spec.S3.CredentialsSecretRef == nil {
+		return nil, nil
+	}
+	ref := store.Spec.S3.CredentialsSecretRef
+
+	key := types.NamespacedName{Namespace: store.Namespace, Name: ref.Name}
+	var secret corev1.Secret
+	if err := r.Get(ctx, key, &secret); err != nil {
+		return nil, fmt.Errorf("ошибка получения Secret %s: %w", key, err)
+	}
+
+	accessKeyID, err := secretValue(&secret, valueOrDefault(ref.AccessKeyIDKey, defaultAccessKeyIDKey))
+	if err != nil {
+		return nil, err
+	}
+	secretAccessKey, err := secretValue(&secret, valueOrDefault(ref.SecretAccessKeyKey, defaultSecretAccessKeyKey))
+	if err != nil {
+		return nil, err
+	}
+	return &nexus.S3Credentials{AccessKeyID: accessKeyID, SecretAccessKey: secretAccessKey}, nil
+}
+
+func (r *BlobStoreReconciler) finalizeBlobStore(
+	ctx context.Context,
+	store *nexusv1alpha1.BlobStore,
+	log logr.Logger,
+) (ctrl.Result, error) {
+	// Удаление хранилища необратимо удаляет его содержимое, поэтому оно включается явно.
+	// Ошибки Nexus записываются в статус, а удаление повторяется с задержкой.
+	if os.Getenv("ENABLE_BLOBSTORE_DELETION") == "true" {
+		nexusClient, err := r.Nexus.APIFor(ctx, store.Namespace, store.Spec.InstanceRef)
+		if err != nil {
+			return r.updateStatus(ctx, store, false, fmt.Errorf("ошибка подключения к Nexus: %w", err))
+		}
+
+		if err := nexusClient.DeleteBlobStore(ctx, store.Spec.Name); err != nil {
+			if !errors.Is(err, nexus.ErrBlobStoreNotFound) {
+				log.Info("Хранилище не удалено в Nexus, удаление отложено", "reason", err.Error())
+				return r.updateStatus(ctx, store, false, fmt.Errorf("ошибка удаления хранилища в Nexus: %w", err))
+			}
+			log.Info("Хранилище уже удалено в Nexus")
+		}
+	}
+
+	store.Finalizers = utils.RemoveString(store.Finalizers, blobStoreFinalizer)
+	if err := r.Update(ctx, store); err != nil {
+		return ctrl.Result{}, fmt.Errorf("ошибка удаления финализатора: %w", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *BlobStoreReconciler) updateStatus(
+	ctx context.Context,
+	store *nexusv1alpha1.BlobStore,
+	ready bool,
+	cause error,
+) (ctrl.Result, error) {
+	newCondition := metav1.Condition{
+		Type:               "Ready",
+		ObservedGeneration: store.Generation,
+	}
+
+	if ready {
+		newCondition.Status = metav1.ConditionTrue
+		newCondition.Reason = successReason
+		newCondition.Message = "Хранилище успешно синхронизировано"
+	} else {
+		newCondition.Status = metav1.ConditionFalse
+		newCondition.Reason = failureReason(cause)
+		newCondition.Message = cause.Error()
+	}
+
+	meta.SetStatusCondition(&store.Status.Conditions, newCondition)
+	if err := r.Status().Update(ctx, store); err != nil {
+		if k8serrors.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("ошибка обновления статуса: %w", err)
+	}
+
+	if ready {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: failureRequeueDelay(cause, blobStoreRequeueDelay)}, nil
+}
+
+// requestsForInstance возвращает хранилища, зависящие от изменённого экземпляра Nexus или его Secret,
+// а также хранилища, ссылающиеся на изменённый Secret с ключом доступа S3.
+func (r *BlobStoreReconciler) requestsForInstance(ctx context.Context, obj client.Object) []reconcile.Request {
+	keys := r.Instances.dependentKeys(ctx, obj)
+	secret, isSecret := obj.(*corev1.Secret)
+	if len(keys) == 0 && !isSecret {
+		return nil
+	}
+
+	var list nexusv1alpha1.BlobStoreList
+	if err := r.List(ctx, &list); err != nil {
+		r.Log.Error(err, "Ошибка получения списка хранилищ")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		_, ok := keys[instanceKey(item.Namespace, item.Spec.InstanceRef)]
+		if ok || (isSecret && usesCredentialsSecret(&item, secret)) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+		}
+	}
+	return requests
+}
+
+// usesCredentialsSecret проверяет, ссылается ли хранилище на Secret с ключом доступа S3.
+func usesCredentialsSecret(store *nexusv1alpha1.BlobStore, secret *corev1.Secret) bool {
+	s3 := store.Spec.S3
+	return s3 != nil && s3.CredentialsSecretRef != nil &&
+		store.Namespace == secret.Namespace && s3.CredentialsSecretRef.Name == secret.Name
+}
+
+func (r *BlobStoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&nexusv1alpha1.BlobStore{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	if err := watchInstanceDependencies(b, r.requestsForInstance).Complete(tracked("BlobStore", r)); err != nil {
+		return fmt.Errorf("не удалось создать контроллер: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	nexusv1alpha1 "github.com/mkostelcev/nexus-operator/api/v1alpha1"
+	"github.com/mkostelcev/nexus-operator/pkg/nexus"
+)
+
+const (
+	cleanupPolicyFinalizer    = "finalizer.nexus.operators.dev.kostoed.ru"
+	cleanupPolicyRequeueDelay = 30 * time.Second
+)
+
+type CleanupPolicyReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+	// Nexus возвращает API Nexus для обрабатываемого ресурса.
+	Nexus APIProvider
+	// Instances используется для отслеживания изменений экземпляров Nexus.
+	Instances *InstanceResolver
+}
+
+//+kubebuilder:rbac:groups=nexus.operators.dev.kostoed.ru,resources=cleanuppolicies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=nexus.operators.dev.kostoed.ru,resources=cleanuppolicies/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=nexus.operators.dev.kostoed.ru,resources=cleanuppolicies/finalizers,verbs=update
+
+func (r *CleanupPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("cleanuppolicy", req.NamespacedName)
+	log.Info("Начало обработки политики очистки")
+
+	var policyCR nexusv1alpha1.CleanupPolicy
+	if err := r.Get(ctx, req.NamespacedName, &policyCR); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("ошибка получения политики очистки: %w", err)
+	}
+
+	res := nexusObjectResource{
+		Object:      &policyCR,
+		Name:        policyCR.Spec.Name,
+		InstanceRef: policyCR.Spec.InstanceRef,
+		Conditions:  &policyCR.Status.Conditions,
+	}
+	return r.objectSync().reconcile(ctx, res, nexus.BuildCleanupPolicyConfig(policyCR.Spec), log)
+}
+
+// objectSync описывает синхронизацию политики очистки с Nexus.
+func (r *CleanupPolicyReconciler) objectSync() *nexusObjectSync[*nexus.CleanupPolicy] {
+	return &nexusObjectSync[*nexus.CleanupPolicy]{
+		Client:       r.Client,
+		Nexus:        r.Nexus,
+		Subject:      "политики очистки",
+		ReadyMessage: "Политика очистки успешно синхронизирована",
+		Finalizer:    cleanupPolicyFinalizer,
+		RequeueDelay: cleanupPolicyRequeueDelay,
+		// Политика может быть назначена репозиториям, которыми оператор не управляет,
+		// поэтому её удаление из Nexus включается явно.
+		DeletionEnv: "ENABLE_CLEANUPPOLICY_DELETION",
+		// REST API политик очистки в OSS и Community появился только в 3.70: раньше Nexus
+		// отвечает 404 или 402, и ресурс получает понятную причину Unsupported.
+		Capabilities: []nexus.Capability{nexus.CapabilityCleanupPolicies},
+		Exists:       nexus.API.CleanupPolicyExists,
+		Get:          nexus.API.GetCleanupPolicy,
+		Create:       nexus.API.CreateCleanupPolicy,
+		Update:       nexus.API.UpdateCleanupPolicy,
+		Delete:       nexus.API.DeleteCleanupPolicy,
+		Diff:         nexus.CleanupPolicyDiff,
+		NotFound:     nexus.ErrCleanupPolicyNotFound,
+	}
+}
+
+// requestsForInstance возвращает политики очистки, зависящие от изменённого экземпляра Nexus или его Secret.
+func (r *CleanupPolicyReconciler) requestsForInstance(ctx context.Context, obj client.Object) []reconcile.Request {
+	keys := r.Instances.dependentKeys(ctx, obj)
+	if len(keys) == 0 {
+		return nil
+	}
+
+	var list nexusv1alpha1.CleanupPolicyList
+	if err := r.List(ctx, &list); err != nil {
+		r.Log.Error(err, "Ошибка получения списка политик очистки")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		if _, ok := keys[instanceKey(item.Namespace, item.Spec.InstanceRef)]; ok {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+		}
+	}
+	return requests
+}
+
+func (r *CleanupPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&nexusv1alpha1.CleanupPolicy{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	if err := watchInstanceDependencies(b, r.requestsForInstance).Complete(tracked("CleanupPolicy", r)); err != nil {
+		return fmt.Errorf("не удалось создать контроллер: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nexusv1alpha1 "github.com/mkostelcev/nexus-operator/api/v1alpha1"
+	"github.com/mkostelcev/nexus-operator/pkg/nexus"
+	"github.com/mkostelcev/nexus-operator/pkg/nexus/fake"
+)
+
+func TestCleanupPolicyKeptWithoutDeletionEnv(t *testing.T) {
+	ctx := context.Background()
+	namespace := newTestNamespace(t)
+	srv, nexusClient := newFakeNexus(t)
+	t.Setenv("ENABLE_CLEANUPPOLICY_DELETION", "")
+
+	r := &CleanupPolicyReconciler{
+		Client: k8sClient,
+		Scheme: scheme,
+		Log:    logr.Discard(),
+		Nexus:  staticAPI{api: nexusClient},
+	}
+
+	days := int32(30)
+	policy := &nexusv1alpha1.CleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "stale-snapshots", Namespace: namespace},
+		Spec: nexusv1alpha1.CleanupPolicySpec{
+			Name:     "stale-snapshots",
+			Format:   "maven2",
+			Criteria: nexusv1alpha1.CleanupPolicyCriteria{LastDownloaded: &days},
+		},
+	}
+	if err := k8sClient.Create(ctx, policy); err != nil {
+		t.Fatalf("ошибка создания ресурса: %v", err)
+	}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(policy)}
+
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if err := k8sClient.Get(ctx, req.NamespacedName, policy); err != nil {
+		t.Fatalf("ошибка получения ресурса: %v", err)
+	}
+	if !meta.IsStatusConditionTrue(policy.Status.Conditions, "Ready") {
+		t.Errorf("условие Ready не выставлено: %+v", policy.Status.Conditions)
+	}
+
+	// Без ENABLE_CLEANUPPOLICY_DELETION политика остаётся в Nexus, а ресурс удаляется.
+	if err := k8sClient.Delete(ctx, policy); err != nil {
+		t.Fatalf("ошибка удаления ресурса: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if _, ok := srv.CleanupPolicy(policy.Spec.Name); !ok {
+		t.Error("политика очистки удалена в Nexus без ENABLE_CLEANUPPOLICY_DELETION")
+	}
+	if err := k8sClient.Get(ctx, req.NamespacedName, policy); !k8serrors.IsNotFound(err) {
+		t.Errorf("ресурс не удалён после снятия финализатора: %v", err)
+	}
+}
+
+func TestCleanupPolicyUnsupportedByOldOSS(t *testing.T) {
+	ctx := context.Background()
+	namespace := newTestNamespace(t)
+	srv, nexusClient := newFakeNexus(t, fake.WithVersion("3.61.0-02", nexus.EditionOSS))
+
+	r := &CleanupPolicyReconciler{
+		Client: k8sClient,
+		Scheme: scheme,
+		Log:    logr.Discard(),
+		Nexus:  staticAPI{api: nexusClient},
+	}
+
+	days := int32(30)
+	policy := &nexusv1alpha1.CleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "stale-snapshots", Namespace: namespace},
+		Spec: nexusv1alpha1.CleanupPolicySpec{
+			Name:     "stale-snapshots",
+			Format:   "maven2",
+			Criteria: nexusv1alpha1.CleanupPolicyCriteria{LastDownloaded: &days},
+		},
+	}
+	if err := k8sClient.Create(ctx, policy); err != nil {
+		t.Fatalf("ошибка создания ресурса: %v", err)
+	}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(policy)}
+
+	// Nexus OSS до 3.70 не предоставляет API политик очистки: запросы к нему не отправляются,
+	// а ресурс получает причину Unsupported.
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if n := srv.RequestCount("", "/service/rest/v1/cleanup-policies"); n != 0 {
+		t.Errorf("запросов к API политик очистки = %d, ожидалось 0", n)
+	}
+	if err := k8sClient.Get(ctx, req.NamespacedName, policy); err != nil {
+		t.Fatalf("ошибка получения ресурса: %v", err)
+	}
+	cond := meta.FindStatusCondition(policy.Status.Conditions, "Ready")
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != unsupportedReason {
+		t.Fatalf("условие Ready = %+v, ожидалась причина %s", cond, unsupportedReason)
+	}
+
+	// Повторная обработка с тем же результатом не обновляет статус.
+	resourceVersion := policy.ResourceVersion
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if err := k8sClient.Get(ctx, req.NamespacedName, policy); err != nil {
+		t.Fatalf("ошибка получения ресурса: %v", err)
+	}
+	if policy.ResourceVersion != resourceVersion {
+		t.Errorf("статус обновлён без изменений: resourceVersion %s -> %s", resourceVersion, policy.ResourceVersion)
+	}
+}
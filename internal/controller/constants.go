@@ -3,4 +3,18 @@ package controller
 const (
 	successReason = "Success"
 	errorReason   = "Error"
+	// nexusUnavailableReason - обращения к Nexus приостановлены автоматическим выключателем.
+	nexusUnavailableReason = "NexusUnavailable"
+	// unsupportedReason - настройки ресурса не поддерживаются версией или редакцией Nexus.
+	unsupportedReason = "Unsupported"
+	// portConflictReason - порт коннектора Docker занят другим репозиторием.
+	portConflictReason = "PortConflict"
+	// dependencyNotReadyReason - ресурс, от которого зависит обрабатываемый, отсутствует или не готов.
+	dependencyNotReadyReason = "DependencyNotReady"
+	// conflictReason - объект с именем, которое использует оператор, создан не им.
+	conflictReason = "Conflict"
+	// inUseReason - объект Nexus нельзя удалить, пока он используется другими объектами.
+	inUseReason = "InUse"
+	// policyViolationReason - спецификация ресурса нарушает политику, заданную флагами оператора.
+	policyViolationReason = "PolicyViolation"
 )
@@ -13,6 +13,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	nexusv1alpha1 "github.com/mkostelcev/nexus-operator/api/v1alpha1"
 	"github.com/mkostelcev/nexus-operator/pkg/nexus"
@@ -28,6 +29,10 @@ type ContentSelectorReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 	Log    logr.Logger
+	// Nexus возвращает API Nexus для обрабатываемого ресурса.
+	Nexus APIProvider
+	// Instances используется для отслеживания изменений экземпляров Nexus.
+	Instances *InstanceResolver
 }
 
 //+kubebuilder:rbac:groups=nexus.operators.dev.kostoed.ru,resources=contentselectors,verbs=get;list;watch;create;update;patch;delete
@@ -65,10 +70,14 @@ func (r *ContentSelectorReconciler) syncContentSelector(
 	cs *nexusv1alpha1.ContentSelector,
 	log logr.Logger,
 ) (ctrl.Result, error) {
-	nexusClient, err := nexus.GetClient()
+	nexusClient, err := r.Nexus.APIFor(ctx, cs.Namespace, cs.Spec.InstanceRef)
 	if err != nil {
 		return r.updateStatus(ctx, cs, false, fmt.Errorf("ошибка подключения к Nexus: %w", err))
 	}
+	if err := nexusClient.Available(); err != nil {
+		log.Info("Обращения к Nexus приостановлены", "reason", err.Error())
+		return r.updateStatus(ctx, cs, false, err)
+	}
 
 	exists, err := nexusClient.ContentSelectorExists(ctx, cs.Spec.Name)
 	if err != nil {
@@ -120,16 +129,16 @@ func (r *ContentSelectorReconciler) finalizeContentSelector(
 	cs *nexusv1alpha1.ContentSelector,
 	log logr.Logger,
 ) (ctrl.Result, error) {
-	nexusClient, err := nexus.GetClient()
+	nexusClient, err := r.Nexus.APIFor(ctx, cs.Namespace, cs.Spec.InstanceRef)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("ошибка подключения к Nexus: %w", err)
+		return r.updateStatus(ctx, cs, false, fmt.Errorf("ошибка подключения к Nexus: %w", err))
 	}
 
 	if err := nexusClient.DeleteContentSelector(ctx, cs.Spec.Name); err != nil {
 		if errors.Is(err, nexus.ErrContentSelectorNotFound) {
 			log.Info("Content Selector уже удален в Nexus")
 		} else {
-			return ctrl.Result{}, fmt.Errorf("ошибка удаления Content Selector в Nexus: %w", err)
+			return r.updateStatus(ctx, cs, false, fmt.Errorf("ошибка удаления Content Selector в Nexus: %w", err))
 		}
 	}
 
@@ -158,7 +167,7 @@ func (r *ContentSelectorReconciler) updateStatus(
 		newCondition.Message = "Content Selector успешно синхронизирован"
 	} else {
 		newCondition.Status = metav1.ConditionFalse
-		newCondition.Reason = errorReason
+		newCondition.Reason = failureReason(cause)
 		newCondition.Message = cause.Error()
 	}
 
@@ -170,13 +179,35 @@ func (r *ContentSelectorReconciler) updateStatus(
 	if ready {
 		return ctrl.Result{}, nil
 	}
-	return ctrl.Result{RequeueAfter: contentSelectorRequeueDelay}, nil
+	return ctrl.Result{RequeueAfter: failureRequeueDelay(cause, contentSelectorRequeueDelay)}, nil
+}
+
+// requestsForInstance возвращает Content Selector, зависящие от изменённого экземпляра Nexus или его Secret.
+func (r *ContentSelectorReconciler) requestsForInstance(ctx context.Context, obj client.Object) []reconcile.Request {
+	keys := r.Instances.dependentKeys(ctx, obj)
+	if len(keys) == 0 {
+		return nil
+	}
+
+	var list nexusv1alpha1.ContentSelectorList
+	if err := r.List(ctx, &list); err != nil {
+		r.Log.Error(err, "Ошибка получения списка Content Selector")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		if _, ok := keys[instanceKey(item.Namespace, item.Spec.InstanceRef)]; ok {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+		}
+	}
+	return requests
 }
 
 func (r *ContentSelectorReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	if err := ctrl.NewControllerManagedBy(mgr).
-		For(&nexusv1alpha1.ContentSelector{}).
-		Complete(r); err != nil {
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&nexusv1alpha1.ContentSelector{})
+	if err := watchInstanceDependencies(b, r.requestsForInstance).Complete(tracked("ContentSelector", r)); err != nil {
 		return fmt.Errorf("не удалось создать контроллер: %w", err)
 	}
 	return nil
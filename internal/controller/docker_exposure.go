@@ -0,0 +1,384 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	nexusv1alpha1 "github.com/mkostelcev/nexus-operator/api/v1alpha1"
+)
+
+var (
+	errDockerExposureMissing  = errors.New("для экземпляра Nexus не задан шаблон dockerExposure")
+	errDockerExposureConflict = errors.New("объект с таким именем уже существует и не создан оператором для этого репозитория")
+)
+
+const (
+	// Метки объектов, созданных для публикации Docker-репозитория.
+	managedByLabel           = "app.kubernetes.io/managed-by"
+	managedByValue           = "nexus-operator"
+	repositoryNameLabel      = "nexus.operators.dev.kostoed.ru/repository-name"
+	repositoryNamespaceLabel = "nexus.operators.dev.kostoed.ru/repository-namespace"
+	// repositoryAnnotation - полное имя репозитория namespace/name: значение метки имени
+	// для длинных имён сокращается.
+	repositoryAnnotation = "nexus.operators.dev.kostoed.ru/repository"
+
+	dockerServicePortName   = "docker"
+	defaultNexusServicePort = 8081
+	// maxObjectNameLength - максимальная длина имени Service (DNS-метка).
+	maxObjectNameLength = 63
+	// maxLabelValueLength - максимальная длина значения метки.
+	maxLabelValueLength = 63
+)
+
+// httpRouteGVK - HTTPRoute из Gateway API. CRD может отсутствовать в кластере, поэтому
+// объект обрабатывается как unstructured, без зависимости от модуля Gateway API.
+var httpRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+
+// reconcileDockerExposure создаёт или обновляет Service и Ingress или HTTPRoute для коннектора
+// Docker-репозитория и удаляет устаревшие объекты. Возвращает адрес для docker pull и docker push.
+func (r *RepositoryReconciler) reconcileDockerExposure(
+	ctx context.Context,
+	repo *nexusv1alpha1.Repository,
+	ports *nexusv1alpha1.DockerPortsStatus,
+	log logr.Logger,
+) (string, error) {
+	if repo.Spec.Docker == nil || !repo.Spec.Docker.Expose {
+		if r.DryRun {
+			return "", nil
+		}
+		return "", r.deleteDockerExposure(ctx, repo)
+	}
+
+	template, err := r.Instances.DockerExposure(ctx, repo.Namespace, repo.Spec.InstanceRef)
+	if err != nil {
+		return "", err
+	}
+	if template == nil {
+		return "", errDockerExposureMissing
+	}
+
+	namespace := valueOrDefault(template.Namespace, repo.Namespace)
+	name := exposureName(repo, namespace)
+	port := exposurePort(template, ports)
+	host := exposureHost(repo, template)
+
+	endpoint := fmt.Sprintf("%s.%s.svc:%d", name, namespace, port)
+	if template.Ingress != nil || template.HTTPRoute != nil {
+		endpoint = host
+	}
+	if r.DryRun {
+		log.Info("Пропущена публикация Docker-репозитория", "namespace", namespace, "name", name, "endpoint", endpoint)
+		return endpoint, nil
+	}
+
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, service, func() error {
+		if err := checkExposureOwnership(repo, service); err != nil {
+			return err
+		}
+		service.Labels = exposureLabels(repo, service.Labels)
+		service.Annotations = exposureAnnotations(repo, service.Annotations)
+		service.Spec.Selector = template.Selector
+		service.Spec.Ports = []corev1.ServicePort{{
+			Name:       dockerServicePortName,
+			Protocol:   corev1.ProtocolTCP,
+			Port:       port,
+			TargetPort: intstr.FromInt32(port),
+		}}
+		return r.setExposureOwner(repo, service)
+	}); err != nil {
+		return "", fmt.Errorf("ошибка сохранения Service %s/%s: %w", namespace, name, err)
+	}
+	keep := []client.Object{service}
+
+	switch {
+	case template.Ingress != nil:
+		ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+		if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, ingress, func() error {
+			if err := checkExposureOwnership(repo, ingress); err != nil {
+				return err
+			}
+			ingress.Labels = exposureLabels(repo, ingress.Labels)
+			ingress.Annotations = exposureAnnotations(repo, maps.Clone(template.Ingress.Annotations))
+			ingress.Spec = ingressSpec(template.Ingress, host, name, port)
+			return r.setExposureOwner(repo, ingress)
+		}); err != nil {
+			return "", fmt.Errorf("ошибка сохранения Ingress %s/%s: %w", namespace, name, err)
+		}
+		keep = append(keep, ingress)
+	case template.HTTPRoute != nil:
+		route := &unstructured.Unstructured{}
+		route.SetGroupVersionKind(httpRouteGVK)
+		route.SetNamespace(namespace)
+		route.SetName(name)
+		if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, route, func() error {
+			if err := checkExposureOwnership(repo, route); err != nil {
+				return err
+			}
+			route.SetLabels(exposureLabels(repo, route.GetLabels()))
+			route.SetAnnotations(exposureAnnotations(repo, maps.Clone(template.HTTPRoute.Annotations)))
+			route.Object["spec"] = httpRouteSpec(template.HTTPRoute, host, name, port)
+			return r.setExposureOwner(repo, route)
+		}); err != nil {
+			return "", fmt.Errorf("ошибка сохранения HTTPRoute %s/%s: %w", namespace, name, err)
+		}
+		keep = append(keep, route)
+	}
+
+	if err := r.deleteDockerExposure(ctx, repo, keep...); err != nil {
+		return "", err
+	}
+	return endpoint, nil
+}
+
+// deleteDockerExposure удаляет объекты публикации репозитория, кроме перечисленных в keep.
+// Объекты ищутся по меткам, так как они могут находиться в другом пространстве имён.
+func (r *RepositoryReconciler) deleteDockerExposure(
+	ctx context.Context,
+	repo *nexusv1alpha1.Repository,
+	keep ...client.Object,
+) error {
+	kept := make(map[string]bool, len(keep))
+	for _, obj := range keep {
+		kept[exposureObjectKey(obj)] = true
+	}
+
+	var services corev1.ServiceList
+	var ingresses networkingv1.IngressList
+	routes := &unstructured.UnstructuredList{}
+	routes.SetGroupVersionKind(httpRouteGVK.GroupVersion().WithKind(httpRouteGVK.Kind + "List"))
+
+	for _, list := range []client.ObjectList{&services, &ingresses, routes} {
+		if err := r.List(ctx, list, client.MatchingLabels(exposureLabels(repo, nil))); err != nil {
+			if meta.IsNoMatchError(err) {
+				// CRD Gateway API не установлен
+				continue
+			}
+			return fmt.Errorf("ошибка получения объектов публикации репозитория: %w", err)
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok || kept[exposureObjectKey(obj)] {
+				continue
+			}
+			if err := r.Delete(ctx, obj); err != nil && !k8serrors.IsNotFound(err) {
+				return fmt.Errorf("ошибка удаления %s: %w", exposureObjectKey(obj), err)
+			}
+		}
+	}
+	return nil
+}
+
+// setExposureOwner делает репозиторий владельцем объекта, если они в одном пространстве имён.
+// Объекты в других пространствах имён удаляются при удалении репозитория по меткам.
+func (r *RepositoryReconciler) setExposureOwner(repo *nexusv1alpha1.Repository, obj client.Object) error {
+	if obj.GetNamespace() != repo.Namespace {
+		return nil
+	}
+	return controllerutil.SetControllerReference(repo, obj, r.Scheme)
+}
+
+// checkExposureOwnership запрещает изменять существующий объект, который оператор не создавал
+// для этого репозитория: совпадение имени не даёт права перезаписать чужой Service или Ingress.
+func checkExposureOwnership(repo *nexusv1alpha1.Repository, obj client.Object) error {
+	if obj.GetResourceVersion() == "" {
+		return nil
+	}
+	labels := obj.GetLabels()
+	for key, value := range exposureLabels(repo, nil) {
+		if labels[key] != value {
+			return fmt.Errorf("%w: %s", errDockerExposureConflict, exposureObjectKey(obj))
+		}
+	}
+	return nil
+}
+
+// requestsForExposure возвращает репозиторий, для которого создан изменённый объект публикации.
+func requestsForExposure(_ context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	if labels[managedByLabel] != managedByValue {
+		return nil
+	}
+	name, namespace := labels[repositoryNameLabel], labels[repositoryNamespaceLabel]
+	if full, ok := obj.GetAnnotations()[repositoryAnnotation]; ok {
+		namespace, name, _ = strings.Cut(full, "/")
+	}
+	if name == "" || namespace == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}}
+}
+
+func ingressSpec(template *nexusv1alpha1.DockerIngressTemplate, host, service string, port int32) networkingv1.IngressSpec {
+	pathType := networkingv1.PathTypePrefix
+	spec := networkingv1.IngressSpec{
+		Rules: []networkingv1.IngressRule{{
+			Host: host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{
+						Path:     "/",
+						PathType: &pathType,
+						Backend: networkingv1.IngressBackend{
+							Service: &networkingv1.IngressServiceBackend{
+								Name: service,
+								Port: networkingv1.ServiceBackendPort{Number: port},
+							},
+						},
+					}},
+				},
+			},
+		}},
+	}
+	if template.ClassName != "" {
+		className := template.ClassName
+		spec.IngressClassName = &className
+	}
+	if template.TLSSecretName != "" {
+		spec.TLS = []networkingv1.IngressTLS{{Hosts: []string{host}, SecretName: template.TLSSecretName}}
+	}
+	return spec
+}
+
+// httpRouteSpec формирует спецификацию HTTPRoute. Значения по умолчанию Gateway API указаны явно,
+// чтобы объект не обновлялся при каждой обработке.
+func httpRouteSpec(template *nexusv1alpha1.DockerHTTPRouteTemplate, host, service string, port int32) map[string]interface{} {
+	parentRefs := make([]interface{}, 0, len(template.ParentRefs))
+	for _, ref := range template.ParentRefs {
+		parent := map[string]interface{}{
+			"group": httpRouteGVK.Group,
+			"kind":  "Gateway",
+			"name":  ref.Name,
+		}
+		if ref.Namespace != "" {
+			parent["namespace"] = ref.Namespace
+		}
+		if ref.SectionName != "" {
+			parent["sectionName"] = ref.SectionName
+		}
+		parentRefs = append(parentRefs, parent)
+	}
+
+	return map[string]interface{}{
+		"parentRefs": parentRefs,
+		"hostnames":  []interface{}{host},
+		"rules": []interface{}{
+			map[string]interface{}{
+				"matches": []interface{}{
+					map[string]interface{}{
+						"path": map[string]interface{}{"type": "PathPrefix", "value": "/"},
+					},
+				},
+				"backendRefs": []interface{}{
+					map[string]interface{}{
+						"group":  "",
+						"kind":   "Service",
+						"name":   service,
+						"port":   int64(port),
+						"weight": int64(1),
+					},
+				},
+			},
+		},
+	}
+}
+
+// exposurePort возвращает порт коннектора: HTTP, затем HTTPS, а без коннектора - основной порт Nexus.
+func exposurePort(template *nexusv1alpha1.DockerExposureTemplate, ports *nexusv1alpha1.DockerPortsStatus) int32 {
+	if ports != nil && ports.HttpPort != nil {
+		return *ports.HttpPort
+	}
+	if ports != nil && ports.HttpsPort != nil {
+		return *ports.HttpsPort
+	}
+	if template.NexusPort != 0 {
+		return template.NexusPort
+	}
+	return defaultNexusServicePort
+}
+
+// exposureHost возвращает имя хоста репозитория: <subdomain>.<domain>.
+func exposureHost(repo *nexusv1alpha1.Repository, template *nexusv1alpha1.DockerExposureTemplate) string {
+	subdomain := valueOrDefault(repo.Spec.Docker.Subdomain, repo.Name)
+	return subdomain + "." + template.Domain
+}
+
+// exposureName возвращает имя объектов публикации. В чужом пространстве имён к имени
+// добавляется пространство имён репозитория, чтобы избежать совпадений.
+func exposureName(repo *nexusv1alpha1.Repository, namespace string) string {
+	name := repo.Name
+	if namespace != repo.Namespace {
+		name = repo.Namespace + "-" + repo.Name
+	}
+	return truncateWithHash(strings.ReplaceAll(name, ".", "-"), maxObjectNameLength)
+}
+
+// exposureLabels добавляет к меткам объекта метки репозитория.
+func exposureLabels(repo *nexusv1alpha1.Repository, labels map[string]string) map[string]string {
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[managedByLabel] = managedByValue
+	labels[repositoryNameLabel] = labelValue(repo.Name)
+	labels[repositoryNamespaceLabel] = repo.Namespace
+	return labels
+}
+
+// exposureAnnotations добавляет к аннотациям объекта полное имя репозитория.
+func exposureAnnotations(repo *nexusv1alpha1.Repository, annotations map[string]string) map[string]string {
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[repositoryAnnotation] = repo.Namespace + "/" + repo.Name
+	return annotations
+}
+
+// labelValue сокращает значение метки до 63 символов.
+func labelValue(value string) string {
+	return truncateWithHash(value, maxLabelValueLength)
+}
+
+// truncateWithHash сокращает значение до maxLength символов, заменяя конец хэшем полного значения,
+// чтобы разные длинные имена не совпадали.
+func truncateWithHash(value string, maxLength int) string {
+	if len(value) <= maxLength {
+		return value
+	}
+	sum := sha256.Sum256([]byte(value))
+	suffix := hex.EncodeToString(sum[:])[:8]
+	return strings.TrimRight(value[:maxLength-len(suffix)-1], "-.") + "-" + suffix
+}
+
+func exposureObjectKey(obj client.Object) string {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	switch obj.(type) {
+	case *corev1.Service:
+		kind = "Service"
+	case *networkingv1.Ingress:
+		kind = "Ingress"
+	}
+	return fmt.Sprintf("%s %s/%s", kind, obj.GetNamespace(), obj.GetName())
+}
@@ -0,0 +1,33 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	nexusv1alpha1 "github.com/mkostelcev/nexus-operator/api/v1alpha1"
+)
+
+func TestExposureNameDistinguishesLongNames(t *testing.T) {
+	prefix := strings.Repeat("docker-hosted-", 5)
+	first := &nexusv1alpha1.Repository{ObjectMeta: metav1.ObjectMeta{Name: prefix + "releases", Namespace: "team-a"}}
+	second := &nexusv1alpha1.Repository{ObjectMeta: metav1.ObjectMeta{Name: prefix + "snapshots", Namespace: "team-a"}}
+
+	for _, namespace := range []string{"team-a", "nexus"} {
+		a, b := exposureName(first, namespace), exposureName(second, namespace)
+		if a == b {
+			t.Errorf("имена объектов публикации совпадают в пространстве имён %s: %s", namespace, a)
+		}
+		for _, name := range []string{a, b} {
+			if len(name) > maxObjectNameLength || strings.HasSuffix(name, "-") {
+				t.Errorf("некорректное имя объекта публикации %q", name)
+			}
+		}
+	}
+
+	short := &nexusv1alpha1.Repository{ObjectMeta: metav1.ObjectMeta{Name: "docker.hosted", Namespace: "team-a"}}
+	if got := exposureName(short, "nexus"); got != "team-a-docker-hosted" {
+		t.Errorf("exposureName = %q, ожидалось team-a-docker-hosted", got)
+	}
+}
@@ -0,0 +1,208 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nexusv1alpha1 "github.com/mkostelcev/nexus-operator/api/v1alpha1"
+)
+
+var (
+	errDockerPortConflict       = errors.New("порт коннектора Docker уже занят")
+	errDockerPortRangeMissing   = errors.New("для экземпляра Nexus не задан диапазон портов dockerPorts")
+	errDockerPortRangeExhausted = errors.New("в диапазоне dockerPorts нет свободных портов")
+)
+
+// dockerPortPool выделяет порты коннекторов Docker в пределах экземпляра Nexus.
+// Занятыми считаются порты, сохранённые в статусе других репозиториев, и порты, выделенные
+// в этом процессе, но ещё не попавшие в статус: кэш может не успеть получить обновлённый статус
+// до обработки следующего репозитория. Нулевое значение готово к использованию.
+type dockerPortPool struct {
+	mu sync.Mutex
+	// reserved - выделенные порты по ключу экземпляра Nexus.
+	reserved map[string]map[int32]types.NamespacedName
+}
+
+// reserve закрепляет порты за репозиторием, освобождая выделенные ему ранее. Вызывается под p.mu.
+func (p *dockerPortPool) reserve(instance string, owner types.NamespacedName, ports []int32) {
+	p.releaseLocked(owner)
+	if p.reserved == nil {
+		p.reserved = make(map[string]map[int32]types.NamespacedName)
+	}
+	if p.reserved[instance] == nil {
+		p.reserved[instance] = make(map[int32]types.NamespacedName)
+	}
+	for _, port := range ports {
+		p.reserved[instance][port] = owner
+	}
+}
+
+// release освобождает порты, выделенные репозиторию.
+func (p *dockerPortPool) release(owner types.NamespacedName) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.releaseLocked(owner)
+}
+
+func (p *dockerPortPool) releaseLocked(owner types.NamespacedName) {
+	for _, ports := range p.reserved {
+		for port, o := range ports {
+			if o == owner {
+				delete(ports, port)
+			}
+		}
+	}
+}
+
+// assignDockerPorts определяет порты коннекторов Docker репозитория: проверяет, что заданные вручную
+// порты не заняты другими репозиториями того же экземпляра, и выделяет порты для значения auto.
+// Nil, если коннекторы не заданы.
+func (r *RepositoryReconciler) assignDockerPorts(
+	ctx context.Context,
+	repo *nexusv1alpha1.Repository,
+) (*nexusv1alpha1.DockerPortsStatus, error) {
+	owner := client.ObjectKeyFromObject(repo)
+	docker := repo.Spec.Docker
+	if docker == nil || (docker.HttpPort == nil && docker.HttpsPort == nil) {
+		r.ports.release(owner)
+		return nil, nil
+	}
+
+	var portRange *nexusv1alpha1.PortRange
+	if isAutoPort(docker.HttpPort) || isAutoPort(docker.HttpsPort) {
+		var err error
+		if portRange, err = r.Instances.DockerPortRange(ctx, repo.Namespace, repo.Spec.InstanceRef); err != nil {
+			return nil, err
+		}
+		if portRange == nil {
+			return nil, errDockerPortRangeMissing
+		}
+	}
+
+	var list nexusv1alpha1.RepositoryList
+	if err := r.List(ctx, &list); err != nil {
+		return nil, fmt.Errorf("ошибка получения списка репозиториев: %w", err)
+	}
+
+	instance := instanceKey(repo.Namespace, repo.Spec.InstanceRef)
+
+	r.ports.mu.Lock()
+	defer r.ports.mu.Unlock()
+
+	// claimed - порты, занятые другими репозиториями, requested - порты, заданные вручную
+	// в спецификации других репозиториев, но ещё не применённые. Порты из requested
+	// не выделяются автоматически, но и не считаются конфликтом для ручного значения.
+	claimed := make(map[int32]string)
+	requested := make(map[int32]bool)
+	for i := range list.Items {
+		item := &list.Items[i]
+		if client.ObjectKeyFromObject(item) == owner || instanceKey(item.Namespace, item.Spec.InstanceRef) != instance {
+			continue
+		}
+		for _, port := range statusDockerPorts(item.Status.DockerPorts) {
+			claimed[port] = client.ObjectKeyFromObject(item).String()
+		}
+		if d := item.Spec.Docker; d != nil {
+			for _, port := range []*intstr.IntOrString{d.HttpPort, d.HttpsPort} {
+				if port != nil && port.Type == intstr.Int {
+					requested[int32(port.IntValue())] = true
+				}
+			}
+		}
+	}
+	for port, o := range r.ports.reserved[instance] {
+		if o != owner {
+			claimed[port] = o.String()
+		}
+	}
+
+	used := make(map[int32]bool)
+	assign := func(spec *intstr.IntOrString, previous *int32) (*int32, error) {
+		if spec == nil {
+			return nil, nil
+		}
+		if !isAutoPort(spec) {
+			port := int32(spec.IntValue())
+			if other, ok := claimed[port]; ok {
+				return nil, fmt.Errorf("%w: %d используется репозиторием %s", errDockerPortConflict, port, other)
+			}
+			if used[port] {
+				return nil, fmt.Errorf("%w: %d указан и для HTTP, и для HTTPS", errDockerPortConflict, port)
+			}
+			used[port] = true
+			return &port, nil
+		}
+
+		// Ранее выделенный порт сохраняется, пока он свободен и входит в диапазон
+		if previous != nil && *previous >= portRange.From && *previous <= portRange.To &&
+			claimed[*previous] == "" && !used[*previous] {
+			port := *previous
+			used[port] = true
+			return &port, nil
+		}
+		for port := portRange.From; port <= portRange.To; port++ {
+			if claimed[port] == "" && !requested[port] && !used[port] {
+				used[port] = true
+				return &port, nil
+			}
+		}
+		return nil, fmt.Errorf("%w: %d-%d", errDockerPortRangeExhausted, portRange.From, portRange.To)
+	}
+
+	previous := repo.Status.DockerPorts
+	if previous == nil {
+		previous = &nexusv1alpha1.DockerPortsStatus{}
+	}
+	var ports nexusv1alpha1.DockerPortsStatus
+	var err error
+	if ports.HttpPort, err = assign(docker.HttpPort, previous.HttpPort); err != nil {
+		return nil, err
+	}
+	if ports.HttpsPort, err = assign(docker.HttpsPort, previous.HttpsPort); err != nil {
+		return nil, err
+	}
+
+	r.ports.reserve(instance, owner, statusDockerPorts(&ports))
+	return &ports, nil
+}
+
+// withDockerPorts возвращает копию репозитория, в которой значения auto заменены выделенными портами.
+func withDockerPorts(repo *nexusv1alpha1.Repository, ports *nexusv1alpha1.DockerPortsStatus) nexusv1alpha1.Repository {
+	resolved := repo.DeepCopy()
+	if ports == nil || resolved.Spec.Docker == nil {
+		return *resolved
+	}
+	if ports.HttpPort != nil {
+		port := intstr.FromInt32(*ports.HttpPort)
+		resolved.Spec.Docker.HttpPort = &port
+	}
+	if ports.HttpsPort != nil {
+		port := intstr.FromInt32(*ports.HttpsPort)
+		resolved.Spec.Docker.HttpsPort = &port
+	}
+	return *resolved
+}
+
+// statusDockerPorts возвращает заданные порты коннекторов.
+func statusDockerPorts(ports *nexusv1alpha1.DockerPortsStatus) []int32 {
+	if ports == nil {
+		return nil
+	}
+	var result []int32
+	for _, port := range []*int32{ports.HttpPort, ports.HttpsPort} {
+		if port != nil {
+			result = append(result, *port)
+		}
+	}
+	return result
+}
+
+func isAutoPort(port *intstr.IntOrString) bool {
+	return port != nil && port.Type == intstr.String && port.StrVal == nexusv1alpha1.DockerPortAuto
+}
@@ -0,0 +1,578 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	nexusv1alpha1 "github.com/mkostelcev/nexus-operator/api/v1alpha1"
+	"github.com/mkostelcev/nexus-operator/pkg/nexus"
+)
+
+var (
+	errUnsupportedInstanceKind = errors.New("неподдерживаемый тип экземпляра Nexus")
+	errNamespaceRequired       = errors.New("для ClusterNexusInstance требуется указать пространство имён объекта")
+	errCrossNamespaceReference = errors.New("NexusInstance не может ссылаться на объект в другом пространстве имён")
+	errSecretKeyMissing        = errors.New("в Secret отсутствует ключ")
+	errConfigMapKeyMissing     = errors.New("в ConfigMap отсутствует ключ")
+)
+
+const (
+	// defaultInstanceKey - ключ экземпляра по умолчанию в кэше клиентов.
+	defaultInstanceKey = "default"
+	// caBundleKey - ключ CA-бандла по умолчанию.
+	caBundleKey = "ca.crt"
+	// instanceSourceField - индекс NexusInstance и ClusterNexusInstance по Secret и ConfigMap,
+	// на которые они ссылаются. Значения имеют вид Kind/namespace/name.
+	instanceSourceField = "spec.sources"
+)
+
+// APIProvider возвращает API Nexus для ресурса из пространства имён namespace
+// с учётом ссылки на экземпляр.
+type APIProvider interface {
+	APIFor(ctx context.Context, namespace string, ref *nexusv1alpha1.InstanceReference) (nexus.API, error)
+}
+
+// InstanceResolver определяет клиент Nexus для ресурса по ссылке instanceRef.
+type InstanceResolver struct {
+	Reader  client.Reader
+	Clients *nexus.ClientCache
+
+	// Decorators оборачивают API, возвращаемое контроллерам (метрики, dry-run и т.п.).
+	Decorators []nexus.Decorator
+
+	// DefaultInstance - экземпляр по умолчанию с учётными данными из Secret.
+	// Если не задан, для ресурсов без instanceRef используется клиент из ENV-переменных.
+	DefaultInstance *nexusv1alpha1.NexusInstanceSpec
+
+	// DefaultDockerPorts - диапазон портов коннекторов Docker для экземпляра по умолчанию.
+	DefaultDockerPorts *nexusv1alpha1.PortRange
+}
+
+// NewInstanceResolver создаёт InstanceResolver с пустым кэшем клиентов.
+func NewInstanceResolver(reader client.Reader) *InstanceResolver {
+	return &InstanceResolver{
+		Reader:  reader,
+		Clients: nexus.NewClientCache(),
+	}
+}
+
+var _ APIProvider = (*InstanceResolver)(nil)
+
+// APIFor возвращает API Nexus для ресурса, обёрнутое декораторами.
+func (r *InstanceResolver) APIFor(
+	ctx context.Context,
+	namespace string,
+	ref *nexusv1alpha1.InstanceReference,
+) (nexus.API, error) {
+	nexusClient, err := r.ClientFor(ctx, namespace, ref)
+	if err != nil {
+		return nil, err
+	}
+	return nexus.Decorate(nexusClient, r.Decorators...), nil
+}
+
+// ClientFor возвращает клиент Nexus для ресурса из пространства имён namespace.
+// Если ссылка не задана, используется клиент по умолчанию из ENV-переменных.
+func (r *InstanceResolver) ClientFor(
+	ctx context.Context,
+	namespace string,
+	ref *nexusv1alpha1.InstanceReference,
+) (*nexus.Client, error) {
+	if ref == nil {
+		if r.DefaultInstance != nil {
+			return r.clientForSpec(ctx, defaultInstanceKey, "", r.DefaultInstance)
+		}
+		return nexus.GetClient()
+	}
+
+	spec, err := r.instanceSpec(ctx, namespace, ref)
+	if err != nil {
+		return nil, err
+	}
+	return r.clientForSpec(ctx, instanceKey(namespace, ref), instanceNamespace(namespace, ref), spec)
+}
+
+// DockerPortRange возвращает диапазон портов коннекторов Docker экземпляра, на который ссылается ресурс.
+// Nil, если диапазон не задан.
+func (r *InstanceResolver) DockerPortRange(
+	ctx context.Context,
+	namespace string,
+	ref *nexusv1alpha1.InstanceReference,
+) (*nexusv1alpha1.PortRange, error) {
+	if ref == nil {
+		return r.DefaultDockerPorts, nil
+	}
+
+	spec, err := r.instanceSpec(ctx, namespace, ref)
+	if err != nil {
+		return nil, err
+	}
+	return spec.DockerPorts, nil
+}
+
+// DockerExposure возвращает шаблон публикации Docker-репозиториев экземпляра, на который ссылается ресурс.
+// Nil, если шаблон не задан. Для экземпляра по умолчанию шаблон не поддерживается. Шаблон NexusInstance
+// может публиковать репозитории только в пространстве имён экземпляра.
+func (r *InstanceResolver) DockerExposure(
+	ctx context.Context,
+	namespace string,
+	ref *nexusv1alpha1.InstanceReference,
+) (*nexusv1alpha1.DockerExposureTemplate, error) {
+	if ref == nil {
+		return nil, nil
+	}
+
+	spec, err := r.instanceSpec(ctx, namespace, ref)
+	if err != nil {
+		return nil, err
+	}
+	template := spec.DockerExposure
+	if ns := instanceNamespace(namespace, ref); ns != "" && template != nil &&
+		template.Namespace != "" && template.Namespace != ns {
+		return nil, fmt.Errorf("%w: dockerExposure.namespace %s", errCrossNamespaceReference, template.Namespace)
+	}
+	return template, nil
+}
+
+// InstanceClient - клиент настроенного экземпляра Nexus или ошибка его создания.
+type InstanceClient struct {
+	Key    string
+	Client *nexus.Client
+	Err    error
+}
+
+// DefaultInstanceClient возвращает клиент экземпляра по умолчанию, настроенного в окружении оператора.
+// Возвращает false, если экземпляр по умолчанию не настроен.
+func (r *InstanceResolver) DefaultInstanceClient(ctx context.Context) (InstanceClient, bool) {
+	if r.DefaultInstance != nil {
+		c, err := r.clientForSpec(ctx, defaultInstanceKey, "", r.DefaultInstance)
+		return InstanceClient{Key: defaultInstanceKey, Client: c, Err: err}, true
+	}
+	c, err := nexus.GetClient()
+	if errors.Is(err, nexus.ErrMissingEnvVars) {
+		return InstanceClient{}, false
+	}
+	return InstanceClient{Key: defaultInstanceKey, Client: c, Err: err}, true
+}
+
+// InstanceClients возвращает клиенты всех NexusInstance и ClusterNexusInstance.
+func (r *InstanceResolver) InstanceClients(ctx context.Context) ([]InstanceClient, error) {
+	var result []InstanceClient
+
+	var instances nexusv1alpha1.NexusInstanceList
+	if err := r.Reader.List(ctx, &instances); err != nil {
+		return nil, fmt.Errorf("ошибка получения списка NexusInstance: %w", err)
+	}
+	for _, item := range instances.Items {
+		key := nexusInstanceKey(item.Namespace, item.Name)
+		c, err := r.clientForSpec(ctx, key, item.Namespace, &item.Spec)
+		result = append(result, InstanceClient{Key: key, Client: c, Err: err})
+	}
+
+	var clusterInstances nexusv1alpha1.ClusterNexusInstanceList
+	if err := r.Reader.List(ctx, &clusterInstances); err != nil {
+		return nil, fmt.Errorf("ошибка получения списка ClusterNexusInstance: %w", err)
+	}
+	for _, item := range clusterInstances.Items {
+		key := clusterNexusInstanceKey(item.Name)
+		c, err := r.clientForSpec(ctx, key, "", &item.Spec)
+		result = append(result, InstanceClient{Key: key, Client: c, Err: err})
+	}
+
+	return result, nil
+}
+
+// clientForSpec возвращает клиент для экземпляра с уже полученной спецификацией.
+func (r *InstanceResolver) clientForSpec(
+	ctx context.Context,
+	key, namespace string,
+	spec *nexusv1alpha1.NexusInstanceSpec,
+) (*nexus.Client, error) {
+	cfg, err := r.clientConfig(ctx, namespace, spec)
+	if err != nil {
+		return nil, err
+	}
+	return r.Clients.Get(key, cfg)
+}
+
+// serverInfo создаёт клиент экземпляра и определяет версию и редакцию Nexus.
+func (r *InstanceResolver) serverInfo(
+	ctx context.Context,
+	key, namespace string,
+	spec *nexusv1alpha1.NexusInstanceSpec,
+) (*nexus.ServerInfo, error) {
+	nexusClient, err := r.clientForSpec(ctx, key, namespace, spec)
+	if err != nil {
+		return nil, err
+	}
+	return nexusClient.ServerInfo(ctx)
+}
+
+// instanceSpec получает спецификацию NexusInstance или ClusterNexusInstance.
+func (r *InstanceResolver) instanceSpec(
+	ctx context.Context,
+	namespace string,
+	ref *nexusv1alpha1.InstanceReference,
+) (*nexusv1alpha1.NexusInstanceSpec, error) {
+	switch instanceKind(ref) {
+	case nexusv1alpha1.NexusInstanceKind:
+		var instance nexusv1alpha1.NexusInstance
+		if err := r.Reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, &instance); err != nil {
+			return nil, fmt.Errorf("ошибка получения NexusInstance %s/%s: %w", namespace, ref.Name, err)
+		}
+		return &instance.Spec, nil
+	case nexusv1alpha1.ClusterNexusInstanceKind:
+		var instance nexusv1alpha1.ClusterNexusInstance
+		if err := r.Reader.Get(ctx, types.NamespacedName{Name: ref.Name}, &instance); err != nil {
+			return nil, fmt.Errorf("ошибка получения ClusterNexusInstance %s: %w", ref.Name, err)
+		}
+		return &instance.Spec, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedInstanceKind, ref.Kind)
+	}
+}
+
+// clientConfig формирует конфигурацию клиента, считывая учётные данные и TLS-настройки
+// из Secret и ConfigMap. namespace - пространство имён экземпляра (пустое для ClusterNexusInstance).
+func (r *InstanceResolver) clientConfig(
+	ctx context.Context,
+	namespace string,
+	spec *nexusv1alpha1.NexusInstanceSpec,
+) (nexus.Config, error) {
+	ref := spec.CredentialsSecretRef
+	secret, err := r.secret(ctx, namespace, ref.ObjectReference)
+	if err != nil {
+		return nexus.Config{}, err
+	}
+
+	username, err := secretValue(secret, valueOrDefault(ref.UsernameKey, "username"))
+	if err != nil {
+		return nexus.Config{}, err
+	}
+	password, err := secretValue(secret, valueOrDefault(ref.PasswordKey, "password"))
+	if err != nil {
+		return nexus.Config{}, err
+	}
+
+	cfg := nexus.Config{
+		BaseURL:  spec.URL,
+		Username: username,
+		Password: password,
+	}
+	if opts := spec.ClientOptions; opts != nil {
+		if opts.Timeout != nil {
+			cfg.Timeout = opts.Timeout.Duration
+		}
+		cfg.Debug = opts.Debug
+		if rl := opts.RateLimit; rl != nil {
+			cfg.QPS = float64(rl.QPS)
+			cfg.Burst = int(rl.Burst)
+		}
+		if cb := opts.CircuitBreaker; cb != nil {
+			cfg.FailureThreshold = int(cb.FailureThreshold)
+			if cb.OpenTimeout != nil {
+				cfg.OpenTimeout = cb.OpenTimeout.Duration
+			}
+		}
+	}
+	if spec.TLS != nil {
+		if cfg.TLS, err = r.tlsConfig(ctx, namespace, spec.TLS); err != nil {
+			return nexus.Config{}, err
+		}
+	}
+	return cfg, nil
+}
+
+// tlsConfig считывает CA-бандл и клиентский сертификат экземпляра.
+func (r *InstanceResolver) tlsConfig(
+	ctx context.Context,
+	namespace string,
+	spec *nexusv1alpha1.TLSConfig,
+) (*nexus.TLSConfig, error) {
+	minVersion, err := nexus.ParseTLSVersion(spec.MinVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &nexus.TLSConfig{
+		ServerName:         spec.ServerName,
+		MinVersion:         minVersion,
+		InsecureSkipVerify: spec.InsecureSkipVerify,
+	}
+
+	if ca := spec.CA; ca != nil {
+		switch {
+		case ca.ConfigMapRef != nil:
+			configMap, err := r.configMap(ctx, namespace, ca.ConfigMapRef.ObjectReference)
+			if err != nil {
+				return nil, err
+			}
+			if cfg.CAPEM, err = configMapValue(configMap, valueOrDefault(ca.ConfigMapRef.Key, caBundleKey)); err != nil {
+				return nil, err
+			}
+		case ca.SecretRef != nil:
+			secret, err := r.secret(ctx, namespace, ca.SecretRef.ObjectReference)
+			if err != nil {
+				return nil, err
+			}
+			value, err := secretValue(secret, valueOrDefault(ca.SecretRef.Key, caBundleKey))
+			if err != nil {
+				return nil, err
+			}
+			cfg.CAPEM = []byte(value)
+		}
+	}
+
+	if ref := spec.ClientCertSecretRef; ref != nil {
+		secret, err := r.secret(ctx, namespace, *ref)
+		if err != nil {
+			return nil, err
+		}
+		cert, err := secretValue(secret, corev1.TLSCertKey)
+		if err != nil {
+			return nil, err
+		}
+		key, err := secretValue(secret, corev1.TLSPrivateKeyKey)
+		if err != nil {
+			return nil, err
+		}
+		cfg.CertPEM, cfg.KeyPEM = []byte(cert), []byte(key)
+	}
+
+	return cfg, nil
+}
+
+// secret получает Secret по ссылке экземпляра.
+func (r *InstanceResolver) secret(
+	ctx context.Context,
+	namespace string,
+	ref nexusv1alpha1.ObjectReference,
+) (*corev1.Secret, error) {
+	key, err := objectKey(namespace, ref)
+	if err != nil {
+		return nil, err
+	}
+	var secret corev1.Secret
+	if err := r.Reader.Get(ctx, key, &secret); err != nil {
+		return nil, fmt.Errorf("ошибка получения Secret %s: %w", key, err)
+	}
+	return &secret, nil
+}
+
+// configMap получает ConfigMap по ссылке экземпляра.
+func (r *InstanceResolver) configMap(
+	ctx context.Context,
+	namespace string,
+	ref nexusv1alpha1.ObjectReference,
+) (*corev1.ConfigMap, error) {
+	key, err := objectKey(namespace, ref)
+	if err != nil {
+		return nil, err
+	}
+	var configMap corev1.ConfigMap
+	if err := r.Reader.Get(ctx, key, &configMap); err != nil {
+		return nil, fmt.Errorf("ошибка получения ConfigMap %s: %w", key, err)
+	}
+	return &configMap, nil
+}
+
+// dependentKeys возвращает ключи экземпляров, на которые влияет изменение объекта:
+// самого NexusInstance/ClusterNexusInstance, Secret или ConfigMap, на которые он ссылается.
+func (r *InstanceResolver) dependentKeys(ctx context.Context, obj client.Object) map[string]struct{} {
+	keys := make(map[string]struct{})
+
+	switch o := obj.(type) {
+	case *nexusv1alpha1.NexusInstance:
+		keys[nexusInstanceKey(o.Namespace, o.Name)] = struct{}{}
+	case *nexusv1alpha1.ClusterNexusInstance:
+		keys[clusterNexusInstanceKey(o.Name)] = struct{}{}
+	case *corev1.Secret, *corev1.ConfigMap:
+		for _, key := range r.instancesUsingSource(ctx, obj) {
+			keys[key] = struct{}{}
+		}
+	}
+	return keys
+}
+
+// instancesUsingSource возвращает ключи экземпляров, ссылающихся на Secret или ConfigMap.
+func (r *InstanceResolver) instancesUsingSource(ctx context.Context, obj client.Object) []string {
+	var keys []string
+
+	if r.DefaultInstance != nil && usesSource(r.DefaultInstance, "", obj) {
+		keys = append(keys, defaultInstanceKey)
+	}
+
+	var instances nexusv1alpha1.NexusInstanceList
+	if err := r.Reader.List(ctx, &instances, matchingSource(obj)); err == nil {
+		for _, item := range instances.Items {
+			keys = append(keys, nexusInstanceKey(item.Namespace, item.Name))
+		}
+	}
+
+	var clusterInstances nexusv1alpha1.ClusterNexusInstanceList
+	if err := r.Reader.List(ctx, &clusterInstances, matchingSource(obj)); err == nil {
+		for _, item := range clusterInstances.Items {
+			keys = append(keys, clusterNexusInstanceKey(item.Name))
+		}
+	}
+
+	return keys
+}
+
+// SetupInstanceIndexes регистрирует индексы NexusInstance и ClusterNexusInstance по Secret и ConfigMap,
+// на которые они ссылаются, чтобы изменение объекта из любого пространства имён находило свои экземпляры.
+func SetupInstanceIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	if err := indexer.IndexField(ctx, &nexusv1alpha1.NexusInstance{}, instanceSourceField,
+		func(obj client.Object) []string {
+			instance := obj.(*nexusv1alpha1.NexusInstance)
+			return instanceSources(&instance.Spec, instance.Namespace)
+		}); err != nil {
+		return fmt.Errorf("ошибка создания индекса NexusInstance: %w", err)
+	}
+	if err := indexer.IndexField(ctx, &nexusv1alpha1.ClusterNexusInstance{}, instanceSourceField,
+		func(obj client.Object) []string {
+			instance := obj.(*nexusv1alpha1.ClusterNexusInstance)
+			return instanceSources(&instance.Spec, "")
+		}); err != nil {
+		return fmt.Errorf("ошибка создания индекса ClusterNexusInstance: %w", err)
+	}
+	return nil
+}
+
+// matchingSource отбирает экземпляры, ссылающиеся на Secret или ConfigMap obj, по индексу instanceSourceField.
+func matchingSource(obj client.Object) client.MatchingFields {
+	return client.MatchingFields{instanceSourceField: sourceKey(obj, obj.GetNamespace(), obj.GetName())}
+}
+
+// watchInstanceDependencies подписывает контроллер на изменения экземпляров Nexus
+// и их Secret/ConfigMap, чтобы повторно обработать зависящие от них ресурсы.
+func watchInstanceDependencies(b *builder.Builder, mapFn handler.MapFunc) *builder.Builder {
+	return b.
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(mapFn)).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(mapFn)).
+		Watches(&nexusv1alpha1.NexusInstance{}, handler.EnqueueRequestsFromMapFunc(mapFn),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&nexusv1alpha1.ClusterNexusInstance{}, handler.EnqueueRequestsFromMapFunc(mapFn),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+}
+
+// usesSource проверяет, ссылается ли спецификация экземпляра на Secret или ConfigMap obj.
+// instanceNamespace используется, если пространство имён в ссылке не задано.
+func usesSource(spec *nexusv1alpha1.NexusInstanceSpec, instanceNamespace string, obj client.Object) bool {
+	return slices.Contains(instanceSources(spec, instanceNamespace), sourceKey(obj, obj.GetNamespace(), obj.GetName()))
+}
+
+// instanceSources возвращает ключи Secret и ConfigMap, на которые ссылается спецификация экземпляра.
+func instanceSources(spec *nexusv1alpha1.NexusInstanceSpec, instanceNamespace string) []string {
+	secret := func(ref nexusv1alpha1.ObjectReference) string {
+		return sourceKey(&corev1.Secret{}, valueOrDefault(ref.Namespace, instanceNamespace), ref.Name)
+	}
+
+	sources := []string{secret(spec.CredentialsSecretRef.ObjectReference)}
+	if tls := spec.TLS; tls != nil {
+		if ca := tls.CA; ca != nil {
+			if ca.SecretRef != nil {
+				sources = append(sources, secret(ca.SecretRef.ObjectReference))
+			}
+			if ref := ca.ConfigMapRef; ref != nil {
+				namespace := valueOrDefault(ref.Namespace, instanceNamespace)
+				sources = append(sources, sourceKey(&corev1.ConfigMap{}, namespace, ref.Name))
+			}
+		}
+		if tls.ClientCertSecretRef != nil {
+			sources = append(sources, secret(*tls.ClientCertSecretRef))
+		}
+	}
+	return sources
+}
+
+// sourceKey формирует значение индекса instanceSourceField для Secret или ConfigMap.
+func sourceKey(obj client.Object, namespace, name string) string {
+	kind := "Secret"
+	if _, ok := obj.(*corev1.ConfigMap); ok {
+		kind = "ConfigMap"
+	}
+	return kind + "/" + namespace + "/" + name
+}
+
+// objectKey формирует ключ объекта по ссылке с учётом пространства имён экземпляра.
+// NexusInstance (instanceNamespace не пустое) ссылается только на объекты своего пространства имён:
+// иначе его владелец мог бы получить чужие учётные данные, указав свой адрес сервера.
+func objectKey(instanceNamespace string, ref nexusv1alpha1.ObjectReference) (types.NamespacedName, error) {
+	if instanceNamespace != "" && ref.Namespace != "" && ref.Namespace != instanceNamespace {
+		return types.NamespacedName{}, fmt.Errorf("%w: %s/%s", errCrossNamespaceReference, ref.Namespace, ref.Name)
+	}
+	namespace := valueOrDefault(ref.Namespace, instanceNamespace)
+	if namespace == "" {
+		return types.NamespacedName{}, fmt.Errorf("%w: %s", errNamespaceRequired, ref.Name)
+	}
+	return types.NamespacedName{Namespace: namespace, Name: ref.Name}, nil
+}
+
+// instanceKind возвращает тип экземпляра с учётом значения по умолчанию.
+func instanceKind(ref *nexusv1alpha1.InstanceReference) string {
+	return valueOrDefault(ref.Kind, nexusv1alpha1.NexusInstanceKind)
+}
+
+// instanceNamespace возвращает пространство имён экземпляра (пустое для кластерного).
+func instanceNamespace(namespace string, ref *nexusv1alpha1.InstanceReference) string {
+	if instanceKind(ref) == nexusv1alpha1.ClusterNexusInstanceKind {
+		return ""
+	}
+	return namespace
+}
+
+// instanceKey формирует ключ экземпляра для кэша клиентов.
+func instanceKey(namespace string, ref *nexusv1alpha1.InstanceReference) string {
+	if ref == nil {
+		return defaultInstanceKey
+	}
+	if ns := instanceNamespace(namespace, ref); ns != "" {
+		return fmt.Sprintf("%s/%s/%s", instanceKind(ref), ns, ref.Name)
+	}
+	return fmt.Sprintf("%s/%s", instanceKind(ref), ref.Name)
+}
+
+// nexusInstanceKey возвращает ключ NexusInstance namespace/name.
+func nexusInstanceKey(namespace, name string) string {
+	return instanceKey(namespace, &nexusv1alpha1.InstanceReference{Kind: nexusv1alpha1.NexusInstanceKind, Name: name})
+}
+
+// clusterNexusInstanceKey возвращает ключ ClusterNexusInstance name.
+func clusterNexusInstanceKey(name string) string {
+	return instanceKey("", &nexusv1alpha1.InstanceReference{Kind: nexusv1alpha1.ClusterNexusInstanceKind, Name: name})
+}
+
+func secretValue(secret *corev1.Secret, key string) (string, error) {
+	value, ok := secret.Data[key]
+	if !ok || len(value) == 0 {
+		return "", fmt.Errorf("%w %q: %s/%s", errSecretKeyMissing, key, secret.Namespace, secret.Name)
+	}
+	return string(value), nil
+}
+
+func configMapValue(configMap *corev1.ConfigMap, key string) ([]byte, error) {
+	if value, ok := configMap.Data[key]; ok && value != "" {
+		return []byte(value), nil
+	}
+	if value, ok := configMap.BinaryData[key]; ok && len(value) > 0 {
+		return value, nil
+	}
+	return nil, fmt.Errorf("%w %q: %s/%s", errConfigMapKeyMissing, key, configMap.Namespace, configMap.Name)
+}
+
+func valueOrDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
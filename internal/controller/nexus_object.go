@@ -0,0 +1,232 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nexusv1alpha1 "github.com/mkostelcev/nexus-operator/api/v1alpha1"
+	"github.com/mkostelcev/nexus-operator/pkg/nexus"
+	"github.com/mkostelcev/nexus-operator/pkg/utils"
+)
+
+// inUseRequeueDelay - задержка повторного удаления объекта, который используется в Nexus.
+const inUseRequeueDelay = 5 * time.Minute
+
+// nexusObjectSync синхронизирует ресурс с одноимённым объектом Nexus: проверяет его существование,
+// создаёт, сравнивает с желаемой конфигурацией, обновляет и удаляет при удалении ресурса.
+// Контроллеры задают только вызовы API для своего типа объекта и тексты сообщений.
+type nexusObjectSync[T any] struct {
+	Client client.Client
+	Nexus  APIProvider
+
+	// Subject - название объекта в родительном падеже для сообщений об ошибках, например "политики очистки".
+	Subject string
+	// ReadyMessage - сообщение условия Ready после успешной синхронизации.
+	ReadyMessage string
+	Finalizer    string
+	RequeueDelay time.Duration
+	// DeletionEnv - ENV-переменная, при значении true которой объект удаляется из Nexus вместе с ресурсом.
+	DeletionEnv string
+	// Capabilities - возможности Nexus, без которых объект нельзя синхронизировать (например, API,
+	// доступный не во всех версиях и редакциях). Nil, если API объекта есть во всех версиях.
+	Capabilities []nexus.Capability
+
+	Exists func(api nexus.API, ctx context.Context, name string) (bool, error)
+	Get    func(api nexus.API, ctx context.Context, name string) (T, error)
+	Create func(api nexus.API, ctx context.Context, desired T) error
+	Update func(api nexus.API, ctx context.Context, name string, desired T) error
+	Delete func(api nexus.API, ctx context.Context, name string) error
+	Diff   func(desired, current T) string
+	// NotFound - ошибка Delete для объекта, уже отсутствующего в Nexus.
+	NotFound error
+	// InUse - ошибка Delete для объекта, который используется в Nexus. Ресурс получает условие
+	// Ready=False с причиной InUse, и удаление повторяется через inUseRequeueDelay. Nil, если
+	// Nexus не отклоняет удаление используемых объектов.
+	InUse error
+}
+
+// nexusObjectResource - ресурс Kubernetes, описывающий объект Nexus.
+type nexusObjectResource struct {
+	client.Object
+	// Name - имя объекта в Nexus.
+	Name        string
+	InstanceRef *nexusv1alpha1.InstanceReference
+	Conditions  *[]metav1.Condition
+}
+
+// reconcile добавляет финализатор и синхронизирует объект Nexus с желаемой конфигурацией
+// или удаляет его, если ресурс удаляется.
+func (s *nexusObjectSync[T]) reconcile(
+	ctx context.Context,
+	res nexusObjectResource,
+	desired T,
+	log logr.Logger,
+) (ctrl.Result, error) {
+	if !res.GetDeletionTimestamp().IsZero() {
+		return s.finalize(ctx, res, log)
+	}
+
+	if !utils.ContainsString(res.GetFinalizers(), s.Finalizer) {
+		res.SetFinalizers(append(res.GetFinalizers(), s.Finalizer))
+		if err := s.Client.Update(ctx, res.Object); err != nil {
+			return ctrl.Result{}, fmt.Errorf("ошибка при добавлении финализатора: %w", err)
+		}
+	}
+
+	return s.sync(ctx, res, desired, log)
+}
+
+func (s *nexusObjectSync[T]) sync(
+	ctx context.Context,
+	res nexusObjectResource,
+	desired T,
+	log logr.Logger,
+) (ctrl.Result, error) {
+	nexusClient, err := s.Nexus.APIFor(ctx, res.GetNamespace(), res.InstanceRef)
+	if err != nil {
+		return s.updateStatus(ctx, res, false, fmt.Errorf("ошибка подключения к Nexus: %w", err))
+	}
+	if err := nexusClient.Available(); err != nil {
+		log.Info("Обращения к Nexus приостановлены", "reason", err.Error())
+		return s.updateStatus(ctx, res, false, err)
+	}
+	if len(s.Capabilities) > 0 {
+		info, err := nexusClient.ServerInfo(ctx)
+		if err != nil {
+			log.Error(err, "Ошибка определения версии Nexus")
+			return s.updateStatus(ctx, res, false, fmt.Errorf("ошибка определения версии Nexus: %w", err))
+		}
+		if err := info.CheckCapabilities(s.Capabilities); err != nil {
+			log.Info("Объект не поддерживается экземпляром Nexus", "reason", err.Error())
+			return s.updateStatus(ctx, res, false, err)
+		}
+	}
+
+	exists, err := s.Exists(nexusClient, ctx, res.Name)
+	if err != nil {
+		return s.updateStatus(ctx, res, false, fmt.Errorf("ошибка проверки %s: %w", s.Subject, err))
+	}
+
+	if !exists {
+		if err := s.Create(nexusClient, ctx, desired); err != nil {
+			return s.updateStatus(ctx, res, false, fmt.Errorf("ошибка создания %s: %w", s.Subject, err))
+		}
+		log.Info("Объект создан в Nexus")
+		return s.updateStatus(ctx, res, true, nil)
+	}
+
+	current, err := s.Get(nexusClient, ctx, res.Name)
+	if err != nil {
+		return s.updateStatus(ctx, res, false, fmt.Errorf("ошибка получения %s: %w", s.Subject, err))
+	}
+
+	if diff := s.Diff(desired, current); diff != "" {
+		log.Info("Обнаружены изменения объекта Nexus", "diff", diff)
+		if err := s.Update(nexusClient, ctx, res.Name, desired); err != nil {
+			return s.updateStatus(ctx, res, false, fmt.Errorf("ошибка обновления %s: %w", s.Subject, err))
+		}
+		log.Info("Объект обновлён в Nexus")
+	}
+
+	return s.updateStatus(ctx, res, true, nil)
+}
+
+// finalize удаляет объект из Nexus, если это разрешено ENV-переменной DeletionEnv, и снимает финализатор.
+// Без разрешения объект остаётся в Nexus: он может использоваться ресурсами, которыми оператор не управляет.
+// Ошибки Nexus записываются в статус, а удаление повторяется с задержкой.
+func (s *nexusObjectSync[T]) finalize(
+	ctx context.Context,
+	res nexusObjectResource,
+	log logr.Logger,
+) (ctrl.Result, error) {
+	if os.Getenv(s.DeletionEnv) == "true" {
+		nexusClient, err := s.Nexus.APIFor(ctx, res.GetNamespace(), res.InstanceRef)
+		if err != nil {
+			return s.updateStatus(ctx, res, false, fmt.Errorf("ошибка подключения к Nexus: %w", err))
+		}
+
+		if err := s.Delete(nexusClient, ctx, res.Name); err != nil {
+			switch {
+			case errors.Is(err, s.NotFound):
+				log.Info("Объект уже удалён в Nexus")
+			case errors.Is(err, s.InUse):
+				// Повтор с нарастающей паузой бесполезен, пока объект не освободят: ресурс получает
+				// условие с причиной InUse, а удаление повторяется с постоянной задержкой.
+				log.Info("Объект используется в Nexus, удаление отложено", "reason", err.Error())
+				result, statusErr := s.updateStatus(ctx, res, false, fmt.Errorf("ошибка удаления %s в Nexus: %w", s.Subject, err))
+				if statusErr != nil || result.Requeue {
+					return result, statusErr
+				}
+				return ctrl.Result{RequeueAfter: inUseRequeueDelay}, nil
+			default:
+				log.Info("Объект не удалён в Nexus, удаление отложено", "reason", err.Error())
+				return s.updateStatus(ctx, res, false, fmt.Errorf("ошибка удаления %s в Nexus: %w", s.Subject, err))
+			}
+		}
+	}
+
+	res.SetFinalizers(utils.RemoveString(res.GetFinalizers(), s.Finalizer))
+	if err := s.Client.Update(ctx, res.Object); err != nil {
+		return ctrl.Result{}, fmt.Errorf("ошибка удаления финализатора: %w", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (s *nexusObjectSync[T]) updateStatus(
+	ctx context.Context,
+	res nexusObjectResource,
+	ready bool,
+	cause error,
+) (ctrl.Result, error) {
+	newCondition := metav1.Condition{
+		Type:               "Ready",
+		ObservedGeneration: res.GetGeneration(),
+	}
+
+	if ready {
+		newCondition.Status = metav1.ConditionTrue
+		newCondition.Reason = successReason
+		newCondition.Message = s.ReadyMessage
+	} else {
+		newCondition.Status = metav1.ConditionFalse
+		newCondition.Reason = failureReason(cause)
+		newCondition.Message = cause.Error()
+	}
+
+	currentCondition := meta.FindStatusCondition(*res.Conditions, "Ready")
+	if currentCondition != nil &&
+		currentCondition.Status == newCondition.Status &&
+		currentCondition.Reason == newCondition.Reason &&
+		currentCondition.Message == newCondition.Message &&
+		currentCondition.ObservedGeneration == newCondition.ObservedGeneration {
+		// Нет изменений
+		if ready {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{RequeueAfter: failureRequeueDelay(cause, s.RequeueDelay)}, nil
+	}
+
+	meta.SetStatusCondition(res.Conditions, newCondition)
+	if err := s.Client.Status().Update(ctx, res.Object); err != nil {
+		if k8serrors.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("ошибка обновления статуса: %w", err)
+	}
+
+	if ready {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: failureRequeueDelay(cause, s.RequeueDelay)}, nil
+}
@@ -0,0 +1,180 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	nexusv1alpha1 "github.com/mkostelcev/nexus-operator/api/v1alpha1"
+	"github.com/mkostelcev/nexus-operator/pkg/nexus"
+)
+
+const instanceRequeueDelay = 30 * time.Second
+
+// NexusInstanceReconciler проверяет конфигурацию NexusInstance.
+type NexusInstanceReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Log       logr.Logger
+	Instances *InstanceResolver
+}
+
+// ClusterNexusInstanceReconciler проверяет конфигурацию ClusterNexusInstance.
+type ClusterNexusInstanceReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Log       logr.Logger
+	Instances *InstanceResolver
+}
+
+//+kubebuilder:rbac:groups=nexus.operators.dev.kostoed.ru,resources=nexusinstances,verbs=get;list;watch
+//+kubebuilder:rbac:groups=nexus.operators.dev.kostoed.ru,resources=nexusinstances/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=nexus.operators.dev.kostoed.ru,resources=clusternexusinstances,verbs=get;list;watch
+//+kubebuilder:rbac:groups=nexus.operators.dev.kostoed.ru,resources=clusternexusinstances/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+
+func (r *NexusInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("nexusinstance", req.NamespacedName)
+	key := nexusInstanceKey(req.Namespace, req.Name)
+
+	var instance nexusv1alpha1.NexusInstance
+	if err := r.Get(ctx, req.NamespacedName, &instance); err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.Info("Экземпляр удалён, клиент исключён из кэша")
+			r.Instances.Clients.Invalidate(key)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("ошибка получения NexusInstance: %w", err)
+	}
+
+	info, err := r.Instances.serverInfo(ctx, key, instance.Namespace, &instance.Spec)
+	return updateInstanceStatus(ctx, r.Client, &instance, &instance.Status, info, err, log)
+}
+
+// requestsForSource возвращает NexusInstance, ссылающиеся на изменённый Secret или ConfigMap.
+func (r *NexusInstanceReconciler) requestsForSource(ctx context.Context, obj client.Object) []reconcile.Request {
+	var list nexusv1alpha1.NexusInstanceList
+	if err := r.List(ctx, &list, matchingSource(obj)); err != nil {
+		r.Log.Error(err, "Ошибка получения списка NexusInstance")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(list.Items))
+	for _, item := range list.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+	}
+	return requests
+}
+
+func (r *NexusInstanceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewControllerManagedBy(mgr).
+		For(&nexusv1alpha1.NexusInstance{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.requestsForSource)).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.requestsForSource)).
+		Complete(tracked("NexusInstance", r)); err != nil {
+		return fmt.Errorf("не удалось создать контроллер: %w", err)
+	}
+	return nil
+}
+
+func (r *ClusterNexusInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("clusternexusinstance", req.Name)
+	key := clusterNexusInstanceKey(req.Name)
+
+	var instance nexusv1alpha1.ClusterNexusInstance
+	if err := r.Get(ctx, req.NamespacedName, &instance); err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.Info("Экземпляр удалён, клиент исключён из кэша")
+			r.Instances.Clients.Invalidate(key)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("ошибка получения ClusterNexusInstance: %w", err)
+	}
+
+	info, err := r.Instances.serverInfo(ctx, key, "", &instance.Spec)
+	return updateInstanceStatus(ctx, r.Client, &instance, &instance.Status, info, err, log)
+}
+
+// requestsForSource возвращает ClusterNexusInstance, ссылающиеся на изменённый Secret или ConfigMap.
+func (r *ClusterNexusInstanceReconciler) requestsForSource(ctx context.Context, obj client.Object) []reconcile.Request {
+	var list nexusv1alpha1.ClusterNexusInstanceList
+	if err := r.List(ctx, &list, matchingSource(obj)); err != nil {
+		r.Log.Error(err, "Ошибка получения списка ClusterNexusInstance")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(list.Items))
+	for _, item := range list.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+	}
+	return requests
+}
+
+func (r *ClusterNexusInstanceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewControllerManagedBy(mgr).
+		For(&nexusv1alpha1.ClusterNexusInstance{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.requestsForSource)).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.requestsForSource)).
+		Complete(tracked("ClusterNexusInstance", r)); err != nil {
+		return fmt.Errorf("не удалось создать контроллер: %w", err)
+	}
+	return nil
+}
+
+// updateInstanceStatus обновляет условие Ready, версию и редакцию экземпляра
+// по результату подключения к Nexus.
+func updateInstanceStatus(
+	ctx context.Context,
+	c client.Client,
+	obj client.Object,
+	status *nexusv1alpha1.NexusInstanceStatus,
+	info *nexus.ServerInfo,
+	cause error,
+	log logr.Logger,
+) (ctrl.Result, error) {
+	newCondition := metav1.Condition{
+		Type:               "Ready",
+		ObservedGeneration: obj.GetGeneration(),
+	}
+
+	if cause == nil {
+		newCondition.Status = metav1.ConditionTrue
+		newCondition.Reason = successReason
+		newCondition.Message = fmt.Sprintf("Подключение к Nexus установлено, %s", info)
+		status.Version = info.Version
+		status.Edition = info.Edition
+	} else {
+		log.Error(cause, "Ошибка подключения к Nexus")
+		newCondition.Status = metav1.ConditionFalse
+		newCondition.Reason = failureReason(cause)
+		newCondition.Message = cause.Error()
+	}
+
+	meta.SetStatusCondition(&status.Conditions, newCondition)
+	status.ObservedGeneration = obj.GetGeneration()
+	if err := c.Status().Update(ctx, obj); err != nil {
+		if k8serrors.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("ошибка обновления статуса: %w", err)
+	}
+
+	if cause == nil {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: failureRequeueDelay(cause, instanceRequeueDelay)}, nil
+}
@@ -7,13 +7,13 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
-	"github.com/google/go-cmp/cmp"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	nexusv1alpha1 "github.com/mkostelcev/nexus-operator/api/v1alpha1"
 	"github.com/mkostelcev/nexus-operator/pkg/nexus"
@@ -29,6 +29,10 @@ type PrivilegeReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 	Log    logr.Logger
+	// Nexus возвращает API Nexus для обрабатываемого ресурса.
+	Nexus APIProvider
+	// Instances используется для отслеживания изменений экземпляров Nexus.
+	Instances *InstanceResolver
 }
 
 //+kubebuilder:rbac:groups=nexus.operators.dev.kostoed.ru,resources=privileges,verbs=get;list;watch;create;update;patch;delete
@@ -66,10 +70,14 @@ func (r *PrivilegeReconciler) syncPrivilege(
 	privilege *nexusv1alpha1.Privilege,
 	log logr.Logger,
 ) (ctrl.Result, error) {
-	nexusClient, err := nexus.GetClient()
+	nexusClient, err := r.Nexus.APIFor(ctx, privilege.Namespace, privilege.Spec.InstanceRef)
 	if err != nil {
 		return r.updateStatus(ctx, privilege, false, fmt.Errorf("ошибка подключения к Nexus: %w", err))
 	}
+	if err := nexusClient.Available(); err != nil {
+		log.Info("Обращения к Nexus приостановлены", "reason", err.Error())
+		return r.updateStatus(ctx, privilege, false, err)
+	}
 
 	exists, err := nexusClient.PrivilegeExists(ctx, privilege.Spec.Name)
 	if err != nil {
@@ -94,7 +102,8 @@ func (r *PrivilegeReconciler) syncPrivilege(
 		return r.updateStatus(ctx, privilege, false, fmt.Errorf("ошибка получения привелегии: %w", err))
 	}
 
-	if r.needsUpdate(currentConfig, desiredConfig) {
+	if diff := nexus.PrivilegeDiff(desiredConfig, currentConfig); diff != "" {
+		log.Info("Обнаружены изменения привелегии", "diff", diff)
 		if err := nexusClient.UpdatePrivilege(ctx, privilege.Spec.Name, desiredConfig); err != nil {
 			return r.updateStatus(ctx, privilege, false, fmt.Errorf("ошибка обновления привелегии: %w", err))
 		}
@@ -104,40 +113,21 @@ func (r *PrivilegeReconciler) syncPrivilege(
 	return r.updateStatus(ctx, privilege, true, nil)
 }
 
-func (r *PrivilegeReconciler) needsUpdate(current, desired map[string]interface{}) bool {
-	ignoreFields := []string{"readOnly", "type", "id"}
-
-	currentCopy := make(map[string]interface{})
-	for k, v := range current {
-		currentCopy[k] = v
-	}
-	desiredCopy := make(map[string]interface{})
-	for k, v := range desired {
-		desiredCopy[k] = v
-	}
-
-	for _, field := range ignoreFields {
-		delete(currentCopy, field)
-		delete(desiredCopy, field)
-	}
-	return !cmp.Equal(currentCopy, desiredCopy)
-}
-
 func (r *PrivilegeReconciler) finalizePrivilege(
 	ctx context.Context,
 	privilege *nexusv1alpha1.Privilege,
 	log logr.Logger,
 ) (ctrl.Result, error) {
-	nexusClient, err := nexus.GetClient()
+	nexusClient, err := r.Nexus.APIFor(ctx, privilege.Namespace, privilege.Spec.InstanceRef)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("ошибка подключения к Nexus: %w", err)
+		return r.updateStatus(ctx, privilege, false, fmt.Errorf("ошибка подключения к Nexus: %w", err))
 	}
 
 	if err := nexusClient.DeletePrivilege(ctx, privilege.Spec.Name); err != nil {
 		if errors.Is(err, nexus.ErrPrivilegeNotFound) {
 			log.Info("Привелегия уже удалена в Nexus")
 		} else {
-			return ctrl.Result{}, fmt.Errorf("ошибка удаления привелегии в Nexus: %w", err)
+			return r.updateStatus(ctx, privilege, false, fmt.Errorf("ошибка удаления привелегии в Nexus: %w", err))
 		}
 	}
 
@@ -166,7 +156,7 @@ func (r *PrivilegeReconciler) updateStatus(
 		newCondition.Message = "Привелегия успешно синхронизирована"
 	} else {
 		newCondition.Status = metav1.ConditionFalse
-		newCondition.Reason = errorReason
+		newCondition.Reason = failureReason(cause)
 		newCondition.Message = cause.Error()
 	}
 
@@ -178,13 +168,35 @@ func (r *PrivilegeReconciler) updateStatus(
 	if ready {
 		return ctrl.Result{}, nil
 	}
-	return ctrl.Result{RequeueAfter: privilegeRequeueDelay}, nil
+	return ctrl.Result{RequeueAfter: failureRequeueDelay(cause, privilegeRequeueDelay)}, nil
+}
+
+// requestsForInstance возвращает привелегии, зависящие от изменённого экземпляра Nexus или его Secret.
+func (r *PrivilegeReconciler) requestsForInstance(ctx context.Context, obj client.Object) []reconcile.Request {
+	keys := r.Instances.dependentKeys(ctx, obj)
+	if len(keys) == 0 {
+		return nil
+	}
+
+	var list nexusv1alpha1.PrivilegeList
+	if err := r.List(ctx, &list); err != nil {
+		r.Log.Error(err, "Ошибка получения списка привелегий")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		if _, ok := keys[instanceKey(item.Namespace, item.Spec.InstanceRef)]; ok {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+		}
+	}
+	return requests
 }
 
 func (r *PrivilegeReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	if err := ctrl.NewControllerManagedBy(mgr).
-		For(&nexusv1alpha1.Privilege{}).
-		Complete(r); err != nil {
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&nexusv1alpha1.Privilege{})
+	if err := watchInstanceDependencies(b, r.requestsForInstance).Complete(tracked("Privilege", r)); err != nil {
 		return fmt.Errorf("не удалось создать контроллер: %w", err)
 	}
 	return nil
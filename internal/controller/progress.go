@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ReconcileTracker получает отметки о начале и завершении обработки ресурсов контроллерами.
+// Используется проверкой живости, чтобы обнаружить контроллер, переставший обрабатывать ресурсы.
+type ReconcileTracker interface {
+	ReconcileStarted(controller string)
+	ReconcileFinished(controller string, err error)
+}
+
+// reconcileTracker задаётся до инициализации контроллеров.
+var reconcileTracker ReconcileTracker
+
+// SetReconcileTracker задаёт получателя отметок для контроллеров, создаваемых после вызова.
+func SetReconcileTracker(tracker ReconcileTracker) {
+	reconcileTracker = tracker
+}
+
+// trackedReconciler передаёт в ReconcileTracker отметки о каждой обработке ресурса.
+type trackedReconciler struct {
+	name    string
+	next    reconcile.Reconciler
+	tracker ReconcileTracker
+}
+
+// tracked оборачивает reconciler контроллера name, если задан ReconcileTracker.
+func tracked(name string, r reconcile.Reconciler) reconcile.Reconciler {
+	if reconcileTracker == nil {
+		return r
+	}
+	return &trackedReconciler{name: name, next: r, tracker: reconcileTracker}
+}
+
+func (t *trackedReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	t.tracker.ReconcileStarted(t.name)
+	result, err := t.next.Reconcile(ctx, req)
+	t.tracker.ReconcileFinished(t.name, err)
+	return result, err
+}
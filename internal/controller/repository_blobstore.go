@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	nexusv1alpha1 "github.com/mkostelcev/nexus-operator/api/v1alpha1"
+	"github.com/mkostelcev/nexus-operator/pkg/nexus"
+)
+
+// checkBlobStore проверяет, что хранилище storage.blobStoreName готово. Если хранилище описано ресурсом
+// BlobStore в пространстве имён репозитория, он должен быть синхронизирован; иначе хранилище должно
+// существовать в Nexus. Существующий репозиторий уже использует своё хранилище, поэтому Nexus
+// проверяется только перед созданием.
+func (r *RepositoryReconciler) checkBlobStore(
+	ctx context.Context,
+	repo *nexusv1alpha1.Repository,
+	api nexus.BlobStoreAPI,
+	exists bool,
+) error {
+	name := repo.Spec.Storage.BlobStoreName
+
+	var list nexusv1alpha1.BlobStoreList
+	if err := r.List(ctx, &list, client.InNamespace(repo.Namespace)); err != nil {
+		return fmt.Errorf("ошибка получения списка хранилищ: %w", err)
+	}
+	instance := instanceKey(repo.Namespace, repo.Spec.InstanceRef)
+	for _, store := range list.Items {
+		if store.Spec.Name != name || instanceKey(store.Namespace, store.Spec.InstanceRef) != instance {
+			continue
+		}
+		if !meta.IsStatusConditionTrue(store.Status.Conditions, "Ready") {
+			return fmt.Errorf("%w: хранилище %s (ресурс %s) не синхронизировано",
+				errDependencyNotReady, name, client.ObjectKeyFromObject(&store))
+		}
+		return nil
+	}
+
+	if exists {
+		return nil
+	}
+	found, err := api.BlobStoreExists(ctx, name)
+	if err != nil {
+		return fmt.Errorf("ошибка проверки хранилища %s: %w", name, err)
+	}
+	if !found {
+		return fmt.Errorf("%w: хранилище %s не найдено в Nexus", errDependencyNotReady, name)
+	}
+	return nil
+}
+
+// requestsForBlobStore возвращает репозитории, использующие изменённое хранилище.
+func (r *RepositoryReconciler) requestsForBlobStore(ctx context.Context, obj client.Object) []reconcile.Request {
+	store, ok := obj.(*nexusv1alpha1.BlobStore)
+	if !ok {
+		return nil
+	}
+
+	var list nexusv1alpha1.RepositoryList
+	if err := r.List(ctx, &list, client.InNamespace(store.Namespace)); err != nil {
+		r.Log.Error(err, "Ошибка получения списка репозиториев")
+		return nil
+	}
+
+	instance := instanceKey(store.Namespace, store.Spec.InstanceRef)
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		if item.Spec.Storage.BlobStoreName == store.Spec.Name && instanceKey(item.Namespace, item.Spec.InstanceRef) == instance {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+		}
+	}
+	return requests
+}
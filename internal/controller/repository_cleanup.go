@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	nexusv1alpha1 "github.com/mkostelcev/nexus-operator/api/v1alpha1"
+)
+
+var errCleanupPolicyInstanceMismatch = errors.New("политика очистки относится к другому экземпляру Nexus")
+
+// resolveCleanupPolicies заменяет ссылки cleanup.policyRefs именами политик в Nexus.
+// Ресурс CleanupPolicy должен находиться в пространстве имён репозитория, относиться к тому же
+// экземпляру Nexus и быть синхронизирован, иначе репозиторий не обрабатывается.
+func (r *RepositoryReconciler) resolveCleanupPolicies(ctx context.Context, repo *nexusv1alpha1.Repository) error {
+	cleanup := repo.Spec.Cleanup
+	if cleanup == nil || len(cleanup.PolicyRefs) == 0 {
+		return nil
+	}
+
+	names := slices.Clone(cleanup.PolicyNames)
+	instance := instanceKey(repo.Namespace, repo.Spec.InstanceRef)
+	for _, ref := range cleanup.PolicyRefs {
+		key := types.NamespacedName{Namespace: repo.Namespace, Name: ref.Name}
+		var policy nexusv1alpha1.CleanupPolicy
+		if err := r.Get(ctx, key, &policy); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return fmt.Errorf("%w: политика очистки %s не найдена", errDependencyNotReady, key)
+			}
+			return fmt.Errorf("ошибка получения политики очистки %s: %w", key, err)
+		}
+		if instanceKey(policy.Namespace, policy.Spec.InstanceRef) != instance {
+			return fmt.Errorf("%w: %s", errCleanupPolicyInstanceMismatch, key)
+		}
+		if !meta.IsStatusConditionTrue(policy.Status.Conditions, "Ready") {
+			return fmt.Errorf("%w: политика очистки %s не синхронизирована", errDependencyNotReady, key)
+		}
+		if !slices.Contains(names, policy.Spec.Name) {
+			names = append(names, policy.Spec.Name)
+		}
+	}
+
+	repo.Spec.Cleanup = &nexusv1alpha1.CleanupConfig{PolicyNames: names}
+	return nil
+}
+
+// requestsForCleanupPolicy возвращает репозитории, ссылающиеся на изменённую политику очистки.
+func (r *RepositoryReconciler) requestsForCleanupPolicy(ctx context.Context, obj client.Object) []reconcile.Request {
+	var list nexusv1alpha1.RepositoryList
+	if err := r.List(ctx, &list, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.Log.Error(err, "Ошибка получения списка репозиториев")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		if item.Spec.Cleanup == nil {
+			continue
+		}
+		for _, ref := range item.Spec.Cleanup.PolicyRefs {
+			if ref.Name == obj.GetName() {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+				break
+			}
+		}
+	}
+	return requests
+}
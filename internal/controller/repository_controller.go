@@ -8,15 +8,20 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
-	"github.com/google/go-cmp/cmp"
-	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	nexusv1alpha1 "github.com/mkostelcev/nexus-operator/api/v1alpha1"
 	"github.com/mkostelcev/nexus-operator/pkg/nexus"
@@ -32,11 +37,46 @@ type RepositoryReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 	Log    logr.Logger
+	// Nexus возвращает API Nexus для обрабатываемого ресурса.
+	Nexus APIProvider
+	// Instances используется для отслеживания изменений экземпляров Nexus.
+	Instances *InstanceResolver
+	// DryRun отключает создание и удаление объектов публикации Docker-репозиториев.
+	DryRun bool
+	// ForbidInlineCredentials запрещает пароль и токен удалённого репозитория, заданные в спецификации
+	// открытым текстом.
+	ForbidInlineCredentials bool
+
+	// ports выделяет порты коннекторов Docker.
+	ports dockerPortPool
+}
+
+// appliedState - значения, которые Nexus не возвращает, поэтому после применения
+// конфигурации они сохраняются в статусе репозитория.
+type appliedState struct {
+	signingKeyHash        string
+	remoteCredentialsHash string
+	dockerPorts           *nexusv1alpha1.DockerPortsStatus
+	dockerEndpoint        string
+}
+
+// matches сообщает, совпадает ли состояние с сохранённым в статусе.
+func (s appliedState) matches(status *nexusv1alpha1.RepositoryStatus) bool {
+	return s.signingKeyHash == status.SigningKeyHash &&
+		s.remoteCredentialsHash == status.RemoteCredentialsHash &&
+		equality.Semantic.DeepEqual(s.dockerPorts, status.DockerPorts) &&
+		s.dockerEndpoint == status.DockerEndpoint
 }
 
 //+kubebuilder:rbac:groups=nexus.operators.dev.kostoed.ru,resources=repositories,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=nexus.operators.dev.kostoed.ru,resources=repositories/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=nexus.operators.dev.kostoed.ru,resources=repositories/finalizers,verbs=update
+//+kubebuilder:rbac:groups=nexus.operators.dev.kostoed.ru,resources=cleanuppolicies,verbs=get;list;watch
+//+kubebuilder:rbac:groups=nexus.operators.dev.kostoed.ru,resources=blobstores,verbs=get;list;watch
+//+kubebuilder:rbac:groups=nexus.operators.dev.kostoed.ru,resources=routingrules,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 
 func (r *RepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("repository", req.NamespacedName)
@@ -73,13 +113,32 @@ func (r *RepositoryReconciler) syncRepository(
 	repo *nexusv1alpha1.Repository,
 	log logr.Logger,
 ) (ctrl.Result, error) {
-	nexusClient, err := nexus.GetClient()
+	if err := r.checkInlineCredentials(repo); err != nil {
+		log.Info("Спецификация репозитория нарушает политику оператора", "reason", err.Error())
+		return r.updateStatus(ctx, repo, false, err)
+	}
+
+	nexusClient, err := r.Nexus.APIFor(ctx, repo.Namespace, repo.Spec.InstanceRef)
 	if err != nil {
 		log.Error(err, "Ошибка создания клиента Nexus")
 		return r.updateStatus(ctx, repo, false, fmt.Errorf("не удалось создать клиент Nexus: %w", err))
 	}
+	if err := nexusClient.Available(); err != nil {
+		log.Info("Обращения к Nexus приостановлены", "reason", err.Error())
+		return r.updateStatus(ctx, repo, false, err)
+	}
 
-	currentConfig, err := nexusClient.GetRepository(ctx, repo.Spec.Name)
+	info, err := nexusClient.ServerInfo(ctx)
+	if err != nil {
+		log.Error(err, "Ошибка определения версии Nexus")
+		return r.updateStatus(ctx, repo, false, fmt.Errorf("ошибка определения версии Nexus: %w", err))
+	}
+	if err := info.CheckCapabilities(nexus.RepositoryCapabilities(repo.Spec)); err != nil {
+		log.Info("Настройки репозитория не поддерживаются экземпляром Nexus", "reason", err.Error())
+		return r.updateStatus(ctx, repo, false, err)
+	}
+
+	currentConfig, err := nexusClient.GetRepository(ctx, repo.Spec.Type, repo.Spec.Name)
 	exists := true
 	if err != nil {
 		if errors.Is(err, nexus.ErrRepositoryNotFound) {
@@ -90,52 +149,92 @@ func (r *RepositoryReconciler) syncRepository(
 		}
 	}
 
-	desiredConfig, err := nexus.BuildRepositoryConfig(*repo)
+	if err := r.checkBlobStore(ctx, repo, nexusClient, exists); err != nil {
+		log.Info("Хранилище репозитория не готово", "reason", err.Error())
+		return r.updateStatus(ctx, repo, false, err)
+	}
+
+	secrets, err := r.repositorySecrets(ctx, repo)
+	if err != nil {
+		log.Error(err, "Ошибка получения секретов репозитория")
+		return r.updateStatus(ctx, repo, false, err)
+	}
+
+	dockerPorts, err := r.assignDockerPorts(ctx, repo)
+	if err != nil {
+		log.Info("Не удалось назначить порты коннекторов Docker", "reason", err.Error())
+		return r.updateStatus(ctx, repo, false, err)
+	}
+
+	resolved := withDockerPorts(repo, dockerPorts)
+	if err := r.resolveCleanupPolicies(ctx, &resolved); err != nil {
+		log.Info("Не удалось определить политики очистки", "reason", err.Error())
+		return r.updateStatus(ctx, repo, false, err)
+	}
+	if err := r.resolveRoutingRule(ctx, &resolved); err != nil {
+		log.Info("Не удалось определить правило маршрутизации", "reason", err.Error())
+		return r.updateStatus(ctx, repo, false, err)
+	}
+
+	desiredConfig, err := nexus.BuildRepositoryConfig(resolved, secrets)
 	if err != nil {
 		log.Error(err, "Ошибка создания конфигурации")
 		return r.updateStatus(ctx, repo, false, fmt.Errorf("ошибка создания конфигурации: %w", err))
 	}
+	if err := nexus.CheckWritableMember(ctx, nexusClient, repo.Spec.Type, desiredConfig.Group); err != nil {
+		log.Error(err, "Ошибка проверки участника группы для публикации")
+		return r.updateStatus(ctx, repo, false, err)
+	}
+	// Объекты публикации создаются только для репозитория, уже существующего в Nexus;
+	// новый репозиторий публикуется в applyConfiguration после создания.
+	dockerEndpoint := ""
+	if exists {
+		if dockerEndpoint, err = r.reconcileDockerExposure(ctx, repo, dockerPorts, log); err != nil {
+			log.Error(err, "Ошибка публикации Docker-репозитория")
+			return r.updateStatus(ctx, repo, false, fmt.Errorf("ошибка публикации Docker-репозитория: %w", err))
+		}
+	}
 
-	if !exists || r.needsUpdate(desiredConfig, currentConfig) {
-		// log.Info("Обнаружены изменения конфигурации", "diff", cmp.Diff(currentConfig, desiredConfig, cmpopts.IgnoreMapEntries(func(k string, v interface{}) bool {
-		// 	return k == "lastUpdated" || k == "taskId" || k == "url"
-		// })))
-		return r.applyConfiguration(ctx, repo, desiredConfig, exists, log)
+	applied := appliedState{
+		signingKeyHash:        secrets.SigningKey.Hash(),
+		remoteCredentialsHash: secrets.RemoteCredentials.Hash(),
+		dockerPorts:           dockerPorts,
+		dockerEndpoint:        dockerEndpoint,
+	}
+
+	if !exists {
+		return r.applyConfiguration(ctx, repo, desiredConfig, applied, false, log)
+	}
+	if diff := nexus.RepositoryDiff(desiredConfig, currentConfig); diff != "" {
+		log.Info("Обнаружены изменения конфигурации", "diff", diff)
+		return r.applyConfiguration(ctx, repo, desiredConfig, applied, true, log)
+	}
+	// Nexus не возвращает ключ подписи и учётные данные, поэтому их изменение определяется по хэшу.
+	// Порты коннекторов сохраняются в статусе, чтобы другие репозитории видели их занятыми.
+	if !applied.matches(&repo.Status) {
+		log.Info("Изменены ключ подписи, учётные данные, порты или адрес коннекторов репозитория")
+		return r.applyConfiguration(ctx, repo, desiredConfig, applied, true, log)
 	}
 
 	log.Info("Конфигурация актуальна")
 	return r.updateStatus(ctx, repo, true, nil)
 }
 
-// Обновляем сравнение конфигураций
-func (r *RepositoryReconciler) needsUpdate(desired, current map[string]interface{}) bool {
-	ignoreFields := cmp.Options{
-		cmpopts.IgnoreMapEntries(func(k string, v interface{}) bool {
-			return k == "lastUpdated" || k == "taskId" || k == "url" || k == "contentDisposition"
-		}),
-		cmp.FilterPath(func(p cmp.Path) bool {
-			// Игнорируем вложенные служебные поля
-			return p.String() == "Attributes.checksum" ||
-				p.String() == "raw.contentDisposition"
-		}, cmp.Ignore()),
-	}
-	return !cmp.Equal(desired, current, ignoreFields)
-}
-
 func (r *RepositoryReconciler) applyConfiguration(
 	ctx context.Context,
 	repo *nexusv1alpha1.Repository,
-	config map[string]interface{},
+	config *nexus.Repository,
+	applied appliedState,
 	exists bool,
 	log logr.Logger,
 ) (ctrl.Result, error) {
-	nexusClient, err := nexus.GetClient()
+	nexusClient, err := r.Nexus.APIFor(ctx, repo.Namespace, repo.Spec.InstanceRef)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("ошибка получения клиента Nexus: %w", err)
 	}
 
 	if exists {
-		if err := nexusClient.UpdateRepository(ctx, repo.Spec.Type, repo.Spec.Name, config); err != nil {
+		if err := nexusClient.UpdateRepository(ctx, repo.Spec.Type, config); err != nil {
 			log.Error(err, "Ошибка обновления репозитория")
 			return r.updateStatus(ctx, repo, false, fmt.Errorf("ошибка обновления: %w", err))
 		}
@@ -146,6 +245,25 @@ func (r *RepositoryReconciler) applyConfiguration(
 			return r.updateStatus(ctx, repo, false, fmt.Errorf("ошибка создания: %w", err))
 		}
 		log.Info("Репозиторий успешно создан")
+
+		if applied.dockerEndpoint, err = r.reconcileDockerExposure(ctx, repo, applied.dockerPorts, log); err != nil {
+			log.Error(err, "Ошибка публикации Docker-репозитория")
+			return r.updateStatus(ctx, repo, false, fmt.Errorf("ошибка публикации Docker-репозитория: %w", err))
+		}
+	}
+
+	if !applied.matches(&repo.Status) {
+		// Условие Ready может не измениться, поэтому состояние сохраняется отдельно.
+		repo.Status.SigningKeyHash = applied.signingKeyHash
+		repo.Status.RemoteCredentialsHash = applied.remoteCredentialsHash
+		repo.Status.DockerPorts = applied.dockerPorts
+		repo.Status.DockerEndpoint = applied.dockerEndpoint
+		if err := r.Status().Update(ctx, repo); err != nil {
+			if k8serrors.IsConflict(err) {
+				return ctrl.Result{Requeue: true}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("ошибка обновления статуса: %w", err)
+		}
 	}
 
 	return r.updateStatus(ctx, repo, true, nil)
@@ -158,19 +276,29 @@ func (r *RepositoryReconciler) finalizeRepository(
 ) (ctrl.Result, error) {
 	log.Info("Начало процедуры удаления репозитория")
 
+	// Ошибки Nexus записываются в статус, а удаление повторяется с задержкой: ошибка обработки
+	// во время недоступности Nexus помешала бы проверке живости оператора.
 	if os.Getenv("ENABLE_REPOSITORY_DELETION") == "true" {
-		nexusClient, err := nexus.GetClient()
+		nexusClient, err := r.Nexus.APIFor(ctx, repo.Namespace, repo.Spec.InstanceRef)
 		if err != nil {
 			log.Error(err, "Ошибка подключения к Nexus")
-			return ctrl.Result{}, fmt.Errorf("ошибка подключения к Nexus: %w", err)
+			return r.updateStatus(ctx, repo, false, fmt.Errorf("ошибка подключения к Nexus: %w", err))
 		}
 
 		if err := nexusClient.DeleteRepository(ctx, repo.Spec.Name); err != nil && !errors.Is(err, nexus.ErrRepositoryNotFound) {
 			log.Error(err, "Ошибка удаления репозитория", "name", repo.Spec.Name)
-			return ctrl.Result{}, fmt.Errorf("ошибка удаления репозитория: %w", err)
+			return r.updateStatus(ctx, repo, false, fmt.Errorf("ошибка удаления репозитория: %w", err))
 		}
 	}
 
+	if !r.DryRun {
+		if err := r.deleteDockerExposure(ctx, repo); err != nil {
+			log.Error(err, "Ошибка удаления объектов публикации репозитория")
+			return ctrl.Result{}, err
+		}
+	}
+
+	r.ports.release(client.ObjectKeyFromObject(repo))
 	repo.Finalizers = utils.RemoveString(repo.Finalizers, repositoryFinalizer)
 	if err := r.Update(ctx, repo); err != nil {
 		log.Error(err, "Ошибка удаления финализатора")
@@ -199,6 +327,7 @@ func (r *RepositoryReconciler) updateStatus(
 		newCondition.Reason = "Success"
 		newCondition.Message = "Репозиторий успешно синхронизирован"
 	} else if cause != nil {
+		newCondition.Reason = failureReason(cause)
 		newCondition.Message = cause.Error()
 	}
 
@@ -212,7 +341,7 @@ func (r *RepositoryReconciler) updateStatus(
 		if ready {
 			return ctrl.Result{}, nil
 		}
-		return ctrl.Result{RequeueAfter: repositoryRequeueDelay}, nil
+		return ctrl.Result{RequeueAfter: failureRequeueDelay(cause, repositoryRequeueDelay)}, nil
 	}
 
 	meta.SetStatusCondition(&repo.Status.Conditions, newCondition)
@@ -228,17 +357,61 @@ func (r *RepositoryReconciler) updateStatus(
 	if ready {
 		return ctrl.Result{}, nil
 	}
-	return ctrl.Result{RequeueAfter: repositoryRequeueDelay}, nil
+	return ctrl.Result{RequeueAfter: failureRequeueDelay(cause, repositoryRequeueDelay)}, nil
+}
+
+// requestsForInstance возвращает репозитории, зависящие от изменённого экземпляра Nexus или его Secret,
+// а также репозитории, ссылающиеся на изменённый Secret (например, с ключом подписи).
+func (r *RepositoryReconciler) requestsForInstance(ctx context.Context, obj client.Object) []reconcile.Request {
+	keys := r.Instances.dependentKeys(ctx, obj)
+	secret, isSecret := obj.(*corev1.Secret)
+	if len(keys) == 0 && !isSecret {
+		return nil
+	}
+
+	var list nexusv1alpha1.RepositoryList
+	if err := r.List(ctx, &list); err != nil {
+		r.Log.Error(err, "Ошибка получения списка репозиториев")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		_, ok := keys[instanceKey(item.Namespace, item.Spec.InstanceRef)]
+		if ok || (isSecret && usesSecret(&item, secret)) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+		}
+	}
+	return requests
 }
 
 func (r *RepositoryReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	err := ctrl.NewControllerManagedBy(mgr).
-		For(&nexusv1alpha1.Repository{}).
-		WithEventFilter(predicate.Or(
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&nexusv1alpha1.Repository{}, builder.WithPredicates(predicate.Or(
 			predicate.GenerationChangedPredicate{},
 			predicate.AnnotationChangedPredicate{},
-		)).
-		Complete(r)
+		)))
+	// Объекты публикации могут находиться в другом пространстве имён, поэтому
+	// репозиторий определяется по меткам, а не по ссылке на владельца.
+	b = b.
+		Watches(&corev1.Service{}, handler.EnqueueRequestsFromMapFunc(requestsForExposure)).
+		Watches(&networkingv1.Ingress{}, handler.EnqueueRequestsFromMapFunc(requestsForExposure)).
+		Watches(&nexusv1alpha1.CleanupPolicy{}, handler.EnqueueRequestsFromMapFunc(r.requestsForCleanupPolicy)).
+		Watches(&nexusv1alpha1.BlobStore{}, handler.EnqueueRequestsFromMapFunc(r.requestsForBlobStore)).
+		Watches(&nexusv1alpha1.RoutingRule{}, handler.EnqueueRequestsFromMapFunc(r.requestsForRoutingRule))
+
+	// CRD Gateway API может отсутствовать в кластере, тогда HTTPRoute не создаются и не отслеживаются.
+	if _, err := mgr.GetRESTMapper().RESTMapping(httpRouteGVK.GroupKind(), httpRouteGVK.Version); err == nil {
+		route := &unstructured.Unstructured{}
+		route.SetGroupVersionKind(httpRouteGVK)
+		b = b.Watches(route, handler.EnqueueRequestsFromMapFunc(requestsForExposure))
+	} else if meta.IsNoMatchError(err) {
+		r.Log.Info("CRD HTTPRoute не установлен, изменения HTTPRoute не отслеживаются")
+	} else {
+		return fmt.Errorf("ошибка проверки CRD HTTPRoute: %w", err)
+	}
+
+	err := watchInstanceDependencies(b, r.requestsForInstance).Complete(tracked("Repository", r))
 
 	if err != nil {
 		return fmt.Errorf("не удалось создать контроллер: %w", err)
@@ -0,0 +1,171 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nexusv1alpha1 "github.com/mkostelcev/nexus-operator/api/v1alpha1"
+	"github.com/mkostelcev/nexus-operator/pkg/nexus"
+	"github.com/mkostelcev/nexus-operator/pkg/nexus/fake"
+	"github.com/mkostelcev/nexus-operator/pkg/utils"
+)
+
+func TestRepositoryReconcile(t *testing.T) {
+	ctx := context.Background()
+	namespace := newTestNamespace(t)
+	srv, nexusClient := newFakeNexus(t)
+	t.Setenv("ENABLE_REPOSITORY_DELETION", "true")
+
+	r := &RepositoryReconciler{
+		Client: k8sClient,
+		Scheme: scheme,
+		Log:    logr.Discard(),
+		Nexus:  staticAPI{api: nexusClient},
+	}
+
+	repo := &nexusv1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{Name: "maven-releases", Namespace: namespace},
+		Spec: nexusv1alpha1.RepositorySpec{
+			Name:    "maven-releases",
+			Type:    nexus.TypeMavenHosted,
+			Online:  true,
+			Storage: nexusv1alpha1.StorageConfig{BlobStoreName: "default", WritePolicy: "ALLOW_ONCE"},
+			Maven:   &nexusv1alpha1.MavenConfig{VersionPolicy: "RELEASE", LayoutPolicy: "STRICT"},
+		},
+	}
+	if err := k8sClient.Create(ctx, repo); err != nil {
+		t.Fatalf("ошибка создания ресурса: %v", err)
+	}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(repo)}
+
+	// Создание: финализатор, репозиторий в Nexus и условие Ready.
+	reconcileRepository(t, r, req)
+	if err := k8sClient.Get(ctx, req.NamespacedName, repo); err != nil {
+		t.Fatalf("ошибка получения ресурса: %v", err)
+	}
+	if !utils.ContainsString(repo.Finalizers, repositoryFinalizer) {
+		t.Errorf("финализатор не добавлен: %v", repo.Finalizers)
+	}
+	if !meta.IsStatusConditionTrue(repo.Status.Conditions, "Ready") {
+		t.Errorf("условие Ready не выставлено: %+v", repo.Status.Conditions)
+	}
+	created, ok := srv.Repository(repo.Spec.Name)
+	if !ok {
+		t.Fatal("репозиторий не создан в Nexus")
+	}
+	if created.Storage.WritePolicy != "ALLOW_ONCE" {
+		t.Errorf("writePolicy = %q, ожидалось ALLOW_ONCE", created.Storage.WritePolicy)
+	}
+
+	// Повторная обработка без изменений не обращается к Nexus с обновлением.
+	srv.ResetRequests()
+	reconcileRepository(t, r, req)
+	if n := srv.RequestCount(http.MethodPut, ""); n != 0 {
+		t.Errorf("запросов PUT без изменений спецификации = %d, ожидалось 0", n)
+	}
+
+	// Изменение спецификации обновляет репозиторий в Nexus.
+	repo.Spec.Storage.WritePolicy = "ALLOW"
+	if err := k8sClient.Update(ctx, repo); err != nil {
+		t.Fatalf("ошибка обновления ресурса: %v", err)
+	}
+	reconcileRepository(t, r, req)
+	if updated, _ := srv.Repository(repo.Spec.Name); updated.Storage.WritePolicy != "ALLOW" {
+		t.Errorf("writePolicy после обновления = %q, ожидалось ALLOW", updated.Storage.WritePolicy)
+	}
+
+	// Удаление ресурса удаляет репозиторий в Nexus и снимает финализатор.
+	if err := k8sClient.Delete(ctx, repo); err != nil {
+		t.Fatalf("ошибка удаления ресурса: %v", err)
+	}
+	reconcileRepository(t, r, req)
+	if _, ok := srv.Repository(repo.Spec.Name); ok {
+		t.Error("репозиторий не удалён в Nexus")
+	}
+	if err := k8sClient.Get(ctx, req.NamespacedName, repo); !k8serrors.IsNotFound(err) {
+		t.Errorf("ресурс не удалён после снятия финализатора: %v", err)
+	}
+}
+
+// reconcileRepository обрабатывает ресурс и проверяет, что обработка завершилась без ошибки и повтора.
+func reconcileRepository(t *testing.T, r *RepositoryReconciler, req ctrl.Request) {
+	t.Helper()
+
+	result, err := r.Reconcile(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if result.Requeue || result.RequeueAfter != 0 {
+		t.Fatalf("Reconcile запросил повтор: %+v", result)
+	}
+}
+
+func TestRepositoryFinalizerDuringNexusOutage(t *testing.T) {
+	ctx := context.Background()
+	namespace := newTestNamespace(t)
+	srv, nexusClient := newFakeNexus(t)
+	t.Setenv("ENABLE_REPOSITORY_DELETION", "true")
+
+	r := &RepositoryReconciler{
+		Client: k8sClient,
+		Scheme: scheme,
+		Log:    logr.Discard(),
+		Nexus:  staticAPI{api: nexusClient},
+	}
+
+	repo := &nexusv1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{Name: "raw-hosted", Namespace: namespace},
+		Spec: nexusv1alpha1.RepositorySpec{
+			Name:    "raw-hosted",
+			Type:    "raw-hosted",
+			Online:  true,
+			Storage: nexusv1alpha1.StorageConfig{BlobStoreName: "default", WritePolicy: "ALLOW"},
+		},
+	}
+	if err := k8sClient.Create(ctx, repo); err != nil {
+		t.Fatalf("ошибка создания ресурса: %v", err)
+	}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(repo)}
+	reconcileRepository(t, r, req)
+
+	// Ошибка Nexus при удалении записывается в статус, а обработка завершается без ошибки,
+	// чтобы отложенное удаление не считалось остановкой контроллера в проверке живости.
+	srv.InjectFault(fake.Fault{Method: http.MethodDelete, Status: http.StatusServiceUnavailable})
+	if err := k8sClient.Delete(ctx, repo); err != nil {
+		t.Fatalf("ошибка удаления ресурса: %v", err)
+	}
+	result, err := r.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatalf("Reconcile вернул ошибку при недоступном Nexus: %v", err)
+	}
+	if result.RequeueAfter == 0 {
+		t.Errorf("удаление не запланировано повторно: %+v", result)
+	}
+	if err := k8sClient.Get(ctx, req.NamespacedName, repo); err != nil {
+		t.Fatalf("ошибка получения ресурса: %v", err)
+	}
+	if meta.IsStatusConditionTrue(repo.Status.Conditions, "Ready") {
+		t.Errorf("ошибка удаления не записана в статус: %+v", repo.Status.Conditions)
+	}
+	if !utils.ContainsString(repo.Finalizers, repositoryFinalizer) {
+		t.Error("финализатор снят до удаления репозитория в Nexus")
+	}
+
+	// После восстановления Nexus удаление завершается.
+	srv.ClearFaults()
+	reconcileRepository(t, r, req)
+	if _, ok := srv.Repository(repo.Spec.Name); ok {
+		t.Error("репозиторий не удалён в Nexus")
+	}
+	if err := k8sClient.Get(ctx, req.NamespacedName, repo); !k8serrors.IsNotFound(err) {
+		t.Errorf("ресурс не удалён после снятия финализатора: %v", err)
+	}
+}
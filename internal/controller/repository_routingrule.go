@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	nexusv1alpha1 "github.com/mkostelcev/nexus-operator/api/v1alpha1"
+)
+
+var errRoutingRuleInstanceMismatch = errors.New("правило маршрутизации относится к другому экземпляру Nexus")
+
+// resolveRoutingRule заменяет ссылку routingRule.ref именем правила в Nexus. Ресурс RoutingRule
+// должен находиться в пространстве имён репозитория, относиться к тому же экземпляру Nexus и быть
+// синхронизирован, иначе репозиторий не обрабатывается.
+func (r *RepositoryReconciler) resolveRoutingRule(ctx context.Context, repo *nexusv1alpha1.Repository) error {
+	assignment := repo.Spec.RoutingRule
+	if assignment == nil || assignment.Ref == nil {
+		return nil
+	}
+
+	key := types.NamespacedName{Namespace: repo.Namespace, Name: assignment.Ref.Name}
+	var rule nexusv1alpha1.RoutingRule
+	if err := r.Get(ctx, key, &rule); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return fmt.Errorf("%w: правило маршрутизации %s не найдено", errDependencyNotReady, key)
+		}
+		return fmt.Errorf("ошибка получения правила маршрутизации %s: %w", key, err)
+	}
+	if instanceKey(rule.Namespace, rule.Spec.InstanceRef) != instanceKey(repo.Namespace, repo.Spec.InstanceRef) {
+		return fmt.Errorf("%w: %s", errRoutingRuleInstanceMismatch, key)
+	}
+	if !meta.IsStatusConditionTrue(rule.Status.Conditions, "Ready") {
+		return fmt.Errorf("%w: правило маршрутизации %s не синхронизировано", errDependencyNotReady, key)
+	}
+
+	repo.Spec.RoutingRule = &nexusv1alpha1.RoutingRuleAssignment{Name: rule.Spec.Name}
+	return nil
+}
+
+// requestsForRoutingRule возвращает репозитории, ссылающиеся на изменённое правило маршрутизации.
+func (r *RepositoryReconciler) requestsForRoutingRule(ctx context.Context, obj client.Object) []reconcile.Request {
+	var list nexusv1alpha1.RepositoryList
+	if err := r.List(ctx, &list, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.Log.Error(err, "Ошибка получения списка репозиториев")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		if rule := item.Spec.RoutingRule; rule != nil && rule.Ref != nil && rule.Ref.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,250 @@
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	nexusv1alpha1 "github.com/mkostelcev/nexus-operator/api/v1alpha1"
+	"github.com/mkostelcev/nexus-operator/pkg/nexus"
+)
+
+const (
+	// defaultKeypairKey и defaultPassphraseKey - ключи Secret с ключом подписи по умолчанию.
+	defaultKeypairKey    = "keypair"
+	defaultPassphraseKey = "passphrase"
+)
+
+var (
+	errInlineCredentials      = errors.New("пароль и токен удалённого репозитория должны задаваться ссылкой на Secret")
+	errDockerConfigInvalid    = errors.New("некорректный Secret типа kubernetes.io/dockerconfigjson")
+	errDockerConfigNoRegistry = errors.New("в Secret нет учётных данных реестра")
+)
+
+// repositorySecrets читает значения из Secret, на которые ссылается спецификация репозитория.
+// Значения передаются только в запросы к Nexus и не попадают в журнал.
+func (r *RepositoryReconciler) repositorySecrets(
+	ctx context.Context,
+	repo *nexusv1alpha1.Repository,
+) (nexus.RepositorySecrets, error) {
+	var secrets nexus.RepositorySecrets
+
+	if signing := repo.Spec.Signing; signing != nil {
+		key, err := r.signingKey(ctx, repo.Namespace, signing.SecretRef)
+		if err != nil {
+			return secrets, fmt.Errorf("ошибка получения ключа подписи: %w", err)
+		}
+		secrets.SigningKey = key
+	}
+	if httpClient := repo.Spec.HttpClient; httpClient != nil && httpClient.Authentication != nil {
+		credentials, err := r.remoteCredentials(ctx, repo, httpClient.Authentication)
+		if err != nil {
+			return secrets, fmt.Errorf("ошибка получения учётных данных удалённого репозитория: %w", err)
+		}
+		secrets.RemoteCredentials = credentials
+	}
+	return secrets, nil
+}
+
+// checkInlineCredentials запрещает пароль и токен удалённого репозитория, заданные в спецификации
+// открытым текстом, если оператор запущен с флагом --forbid-inline-credentials.
+func (r *RepositoryReconciler) checkInlineCredentials(repo *nexusv1alpha1.Repository) error {
+	if !r.ForbidInlineCredentials || repo.Spec.HttpClient == nil || repo.Spec.HttpClient.Authentication == nil {
+		return nil
+	}
+	auth := repo.Spec.HttpClient.Authentication
+	if auth.Password != "" || auth.BearerToken != "" {
+		return fmt.Errorf("%w: используйте passwordSecretRef, bearerTokenSecretRef или dockerConfigSecretRef",
+			errInlineCredentials)
+	}
+	return nil
+}
+
+// remoteCredentials возвращает учётные данные удалённого репозитория с учётом ссылок на Secret.
+// Значения, заданные в спецификации, используются для полей без ссылок, чтобы изменение любого из них
+// отслеживалось по хэшу.
+func (r *RepositoryReconciler) remoteCredentials(
+	ctx context.Context,
+	repo *nexusv1alpha1.Repository,
+	auth *nexusv1alpha1.AuthConfig,
+) (*nexus.RemoteCredentials, error) {
+	if ref := auth.DockerConfigSecretRef; ref != nil {
+		registry := ref.Registry
+		if registry == "" && repo.Spec.Proxy != nil {
+			registry = repo.Spec.Proxy.RemoteUrl
+		}
+		return r.dockerConfigCredentials(ctx, repo.Namespace, ref.Name, registry)
+	}
+
+	credentials := &nexus.RemoteCredentials{
+		Username:    auth.Username,
+		Password:    auth.Password,
+		BearerToken: auth.BearerToken,
+	}
+	for _, field := range []struct {
+		ref   *nexusv1alpha1.SecretKeyReference
+		value *string
+	}{
+		{auth.UsernameSecretRef, &credentials.Username},
+		{auth.PasswordSecretRef, &credentials.Password},
+		{auth.BearerTokenSecretRef, &credentials.BearerToken},
+	} {
+		if field.ref == nil {
+			continue
+		}
+		secret, err := r.secret(ctx, repo.Namespace, field.ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		if *field.value, err = secretValue(secret, field.ref.Key); err != nil {
+			return nil, err
+		}
+	}
+	return credentials, nil
+}
+
+// dockerConfig - содержимое ключа .dockerconfigjson.
+type dockerConfig struct {
+	Auths map[string]struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Auth     string `json:"auth"`
+	} `json:"auths"`
+}
+
+// dockerConfigCredentials читает учётные данные реестра registry из Secret типа kubernetes.io/dockerconfigjson.
+func (r *RepositoryReconciler) dockerConfigCredentials(
+	ctx context.Context,
+	namespace, name, registry string,
+) (*nexus.RemoteCredentials, error) {
+	secret, err := r.secret(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := secretValue(secret, corev1.DockerConfigJsonKey)
+	if err != nil {
+		return nil, err
+	}
+	var config dockerConfig
+	if err := json.Unmarshal([]byte(data), &config); err != nil {
+		return nil, fmt.Errorf("%w %s/%s: %w", errDockerConfigInvalid, namespace, name, err)
+	}
+
+	// Точное совпадение адреса предпочтительнее; остальные записи того же хоста
+	// перебираются в отсортированном порядке, чтобы результат не зависел от порядка ключей.
+	host := registryHost(registry)
+	servers := make([]string, 0, len(config.Auths))
+	for server := range config.Auths {
+		if server != registry && registryHost(server) == host {
+			servers = append(servers, server)
+		}
+	}
+	sort.Strings(servers)
+	if _, ok := config.Auths[registry]; ok {
+		servers = append([]string{registry}, servers...)
+	}
+
+	for _, server := range servers {
+		entry := config.Auths[server]
+		username, password := entry.Username, entry.Password
+		if username == "" && entry.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("%w %s/%s: поле auth реестра %s: %w", errDockerConfigInvalid, namespace, name, server, err)
+			}
+			username, password, _ = strings.Cut(string(decoded), ":")
+		}
+		return &nexus.RemoteCredentials{Username: username, Password: password}, nil
+	}
+	return nil, fmt.Errorf("%w %q: %s/%s", errDockerConfigNoRegistry, host, namespace, name)
+}
+
+// registryHost приводит адрес реестра к имени хоста. Адреса Docker Hub (docker.io, index.docker.io,
+// registry-1.docker.io) считаются одним реестром.
+func registryHost(registry string) string {
+	host := registry
+	if u, err := url.Parse(registry); err == nil && u.Host != "" {
+		host = u.Host
+	} else {
+		host, _, _ = strings.Cut(host, "/")
+	}
+	host = strings.ToLower(host)
+	switch host {
+	case "index.docker.io", "registry-1.docker.io":
+		return "docker.io"
+	}
+	return host
+}
+
+// signingKey читает ключ GPG и парольную фразу из Secret.
+func (r *RepositoryReconciler) signingKey(
+	ctx context.Context,
+	namespace string,
+	ref nexusv1alpha1.SigningKeySecretReference,
+) (*nexus.SigningKey, error) {
+	secret, err := r.secret(ctx, namespace, ref.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	keypair, err := secretValue(secret, valueOrDefault(ref.KeypairKey, defaultKeypairKey))
+	if err != nil {
+		return nil, err
+	}
+	// Парольная фраза необязательна: ключ может быть создан без неё.
+	passphrase := string(secret.Data[valueOrDefault(ref.PassphraseKey, defaultPassphraseKey)])
+
+	return &nexus.SigningKey{Keypair: keypair, Passphrase: passphrase}, nil
+}
+
+func (r *RepositoryReconciler) secret(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	key := types.NamespacedName{Namespace: namespace, Name: name}
+	var secret corev1.Secret
+	if err := r.Get(ctx, key, &secret); err != nil {
+		return nil, fmt.Errorf("ошибка получения Secret %s: %w", key, err)
+	}
+	return &secret, nil
+}
+
+// repositorySecretNames возвращает имена Secret, на которые ссылается спецификация репозитория.
+func repositorySecretNames(spec *nexusv1alpha1.RepositorySpec) []string {
+	var names []string
+	if spec.Signing != nil {
+		names = append(names, spec.Signing.SecretRef.Name)
+	}
+	if spec.HttpClient != nil && spec.HttpClient.Authentication != nil {
+		auth := spec.HttpClient.Authentication
+		for _, ref := range []*nexusv1alpha1.SecretKeyReference{
+			auth.UsernameSecretRef, auth.PasswordSecretRef, auth.BearerTokenSecretRef,
+		} {
+			if ref != nil {
+				names = append(names, ref.Name)
+			}
+		}
+		if auth.DockerConfigSecretRef != nil {
+			names = append(names, auth.DockerConfigSecretRef.Name)
+		}
+	}
+	return names
+}
+
+// usesSecret проверяет, ссылается ли репозиторий на Secret.
+func usesSecret(repo *nexusv1alpha1.Repository, secret *corev1.Secret) bool {
+	if repo.Namespace != secret.Namespace {
+		return false
+	}
+	for _, name := range repositorySecretNames(&repo.Spec) {
+		if name == secret.Name {
+			return true
+		}
+	}
+	return false
+}
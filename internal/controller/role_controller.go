@@ -13,6 +13,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	nexusv1alpha1 "github.com/mkostelcev/nexus-operator/api/v1alpha1"
 	"github.com/mkostelcev/nexus-operator/pkg/nexus"
@@ -27,6 +28,10 @@ type RoleReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 	Log    logr.Logger
+	// Nexus возвращает API Nexus для обрабатываемого ресурса.
+	Nexus APIProvider
+	// Instances используется для отслеживания изменений экземпляров Nexus.
+	Instances *InstanceResolver
 }
 
 // +kubebuilder:rbac:groups=nexus.operators.dev.kostoed.ru,resources=roles,verbs=get;list;watch;create;update;patch;delete
@@ -66,10 +71,14 @@ func (r *RoleReconciler) syncRole(
 	role *nexusv1alpha1.Role,
 	log logr.Logger,
 ) (ctrl.Result, error) {
-	nexusClient, err := nexus.GetClient()
+	nexusClient, err := r.Nexus.APIFor(ctx, role.Namespace, role.Spec.InstanceRef)
 	if err != nil {
 		return r.updateStatus(ctx, role, false, fmt.Errorf("ошибка подключения к Nexus: %w", err))
 	}
+	if err := nexusClient.Available(); err != nil {
+		log.Info("Обращения к Nexus приостановлены", "reason", err.Error())
+		return r.updateStatus(ctx, role, false, err)
+	}
 
 	desiredRole := nexus.BuildRoleConfig(role.Spec)
 
@@ -116,16 +125,16 @@ func (r *RoleReconciler) finalizeRole(
 ) (ctrl.Result, error) {
 	log.Info("Запуск процедуры удаления роли")
 
-	nexusClient, err := nexus.GetClient()
+	nexusClient, err := r.Nexus.APIFor(ctx, role.Namespace, role.Spec.InstanceRef)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("ошибка подключения к Nexus: %w", err)
+		return r.updateStatus(ctx, role, false, fmt.Errorf("ошибка подключения к Nexus: %w", err))
 	}
 
 	if err := nexusClient.DeleteRole(ctx, role.Spec.RoleID); err != nil {
 		if errors.Is(err, nexus.ErrRoleNotFound) {
 			log.Info("Роль уже удалена в Nexus")
 		} else {
-			return ctrl.Result{}, fmt.Errorf("ошибка удаления роли из Nexus: %w", err)
+			return r.updateStatus(ctx, role, false, fmt.Errorf("ошибка удаления роли из Nexus: %w", err))
 		}
 	}
 
@@ -155,7 +164,7 @@ func (r *RoleReconciler) updateStatus(
 		newCondition.Message = "Роль синхронизирована с Nexus"
 	} else {
 		newCondition.Status = metav1.ConditionFalse
-		newCondition.Reason = "Error"
+		newCondition.Reason = failureReason(cause)
 		newCondition.Message = cause.Error()
 	}
 
@@ -167,13 +176,35 @@ func (r *RoleReconciler) updateStatus(
 	if ready {
 		return ctrl.Result{}, nil
 	}
-	return ctrl.Result{RequeueAfter: roleRequeueDelay}, nil
+	return ctrl.Result{RequeueAfter: failureRequeueDelay(cause, roleRequeueDelay)}, nil
+}
+
+// requestsForInstance возвращает роли, зависящие от изменённого экземпляра Nexus или его Secret.
+func (r *RoleReconciler) requestsForInstance(ctx context.Context, obj client.Object) []reconcile.Request {
+	keys := r.Instances.dependentKeys(ctx, obj)
+	if len(keys) == 0 {
+		return nil
+	}
+
+	var list nexusv1alpha1.RoleList
+	if err := r.List(ctx, &list); err != nil {
+		r.Log.Error(err, "Ошибка получения списка ролей")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		if _, ok := keys[instanceKey(item.Namespace, item.Spec.InstanceRef)]; ok {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+		}
+	}
+	return requests
 }
 
 func (r *RoleReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	if err := ctrl.NewControllerManagedBy(mgr).
-		For(&nexusv1alpha1.Role{}).
-		Complete(r); err != nil {
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&nexusv1alpha1.Role{})
+	if err := watchInstanceDependencies(b, r.requestsForInstance).Complete(tracked("Role", r)); err != nil {
 		return fmt.Errorf("не удалось создать контроллер: %w", err)
 	}
 	return nil
@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	nexusv1alpha1 "github.com/mkostelcev/nexus-operator/api/v1alpha1"
+	"github.com/mkostelcev/nexus-operator/pkg/nexus"
+)
+
+const (
+	routingRuleFinalizer    = "finalizer.nexus.operators.dev.kostoed.ru"
+	routingRuleRequeueDelay = 30 * time.Second
+)
+
+type RoutingRuleReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+	// Nexus возвращает API Nexus для обрабатываемого ресурса.
+	Nexus APIProvider
+	// Instances используется для отслеживания изменений экземпляров Nexus.
+	Instances *InstanceResolver
+}
+
+//+kubebuilder:rbac:groups=nexus.operators.dev.kostoed.ru,resources=routingrules,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=nexus.operators.dev.kostoed.ru,resources=routingrules/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=nexus.operators.dev.kostoed.ru,resources=routingrules/finalizers,verbs=update
+
+func (r *RoutingRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("routingrule", req.NamespacedName)
+	log.Info("Начало обработки правила маршрутизации")
+
+	var ruleCR nexusv1alpha1.RoutingRule
+	if err := r.Get(ctx, req.NamespacedName, &ruleCR); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("ошибка получения правила маршрутизации: %w", err)
+	}
+
+	res := nexusObjectResource{
+		Object:      &ruleCR,
+		Name:        ruleCR.Spec.Name,
+		InstanceRef: ruleCR.Spec.InstanceRef,
+		Conditions:  &ruleCR.Status.Conditions,
+	}
+	return r.objectSync().reconcile(ctx, res, nexus.BuildRoutingRuleConfig(ruleCR.Spec), log)
+}
+
+// objectSync описывает синхронизацию правила маршрутизации с Nexus.
+func (r *RoutingRuleReconciler) objectSync() *nexusObjectSync[*nexus.RoutingRule] {
+	return &nexusObjectSync[*nexus.RoutingRule]{
+		Client:       r.Client,
+		Nexus:        r.Nexus,
+		Subject:      "правила маршрутизации",
+		ReadyMessage: "Правило маршрутизации успешно синхронизировано",
+		Finalizer:    routingRuleFinalizer,
+		RequeueDelay: routingRuleRequeueDelay,
+		// Правило может быть назначено репозиториям, которыми оператор не управляет,
+		// поэтому его удаление из Nexus включается явно.
+		DeletionEnv: "ENABLE_ROUTINGRULE_DELETION",
+		Exists:      nexus.API.RoutingRuleExists,
+		Get:         nexus.API.GetRoutingRule,
+		Create:      nexus.API.CreateRoutingRule,
+		Update:      nexus.API.UpdateRoutingRule,
+		Delete:      nexus.API.DeleteRoutingRule,
+		Diff:        nexus.RoutingRuleDiff,
+		NotFound:    nexus.ErrRoutingRuleNotFound,
+		InUse:       nexus.ErrRoutingRuleInUse,
+	}
+}
+
+// requestsForInstance возвращает правила маршрутизации, зависящие от изменённого экземпляра Nexus или его Secret.
+func (r *RoutingRuleReconciler) requestsForInstance(ctx context.Context, obj client.Object) []reconcile.Request {
+	keys := r.Instances.dependentKeys(ctx, obj)
+	if len(keys) == 0 {
+		return nil
+	}
+
+	var list nexusv1alpha1.RoutingRuleList
+	if err := r.List(ctx, &list); err != nil {
+		r.Log.Error(err, "Ошибка получения списка правил маршрутизации")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		if _, ok := keys[instanceKey(item.Namespace, item.Spec.InstanceRef)]; ok {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+		}
+	}
+	return requests
+}
+
+// requestsForRepository возвращает удаляемые правила маршрутизации в пространстве имён изменённого
+// репозитория: правило, которое было назначено репозиторию, может освободиться.
+func (r *RoutingRuleReconciler) requestsForRepository(ctx context.Context, obj client.Object) []reconcile.Request {
+	var list nexusv1alpha1.RoutingRuleList
+	if err := r.List(ctx, &list, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.Log.Error(err, "Ошибка получения списка правил маршрутизации")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		if !item.DeletionTimestamp.IsZero() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+		}
+	}
+	return requests
+}
+
+func (r *RoutingRuleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&nexusv1alpha1.RoutingRule{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&nexusv1alpha1.Repository{}, handler.EnqueueRequestsFromMapFunc(r.requestsForRepository))
+	if err := watchInstanceDependencies(b, r.requestsForInstance).Complete(tracked("RoutingRule", r)); err != nil {
+		return fmt.Errorf("не удалось создать контроллер: %w", err)
+	}
+	return nil
+}
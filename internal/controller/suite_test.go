@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	nexusv1alpha1 "github.com/mkostelcev/nexus-operator/api/v1alpha1"
+	"github.com/mkostelcev/nexus-operator/pkg/nexus"
+	"github.com/mkostelcev/nexus-operator/pkg/nexus/fake"
+)
+
+// k8sClient - клиент API-сервера envtest. Nil, если не задана переменная KUBEBUILDER_ASSETS
+// с путём к бинарным файлам etcd и kube-apiserver (make test задаёт её сама).
+var (
+	k8sClient client.Client
+	scheme    = runtime.NewScheme()
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(runTests(m))
+}
+
+func runTests(m *testing.M) int {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		return m.Run()
+	}
+
+	testEnv := &envtest.Environment{
+		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "config", "crd", "bases")},
+		ErrorIfCRDPathMissing: true,
+	}
+	cfg, err := testEnv.Start()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ошибка запуска envtest: %v\n", err)
+		return 1
+	}
+	defer func() {
+		if err := testEnv.Stop(); err != nil {
+			fmt.Fprintf(os.Stderr, "ошибка остановки envtest: %v\n", err)
+		}
+	}()
+
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		fmt.Fprintf(os.Stderr, "ошибка регистрации типов: %v\n", err)
+		return 1
+	}
+	if err := nexusv1alpha1.AddToScheme(scheme); err != nil {
+		fmt.Fprintf(os.Stderr, "ошибка регистрации типов: %v\n", err)
+		return 1
+	}
+	if k8sClient, err = client.New(cfg, client.Options{Scheme: scheme}); err != nil {
+		fmt.Fprintf(os.Stderr, "ошибка создания клиента: %v\n", err)
+		return 1
+	}
+
+	return m.Run()
+}
+
+// newTestNamespace пропускает тест без envtest и создаёт отдельное пространство имён для теста.
+func newTestNamespace(t *testing.T) string {
+	t.Helper()
+	if k8sClient == nil {
+		t.Skip("KUBEBUILDER_ASSETS не задана, тест с envtest пропущен")
+	}
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: "nexus-operator-test-"}}
+	if err := k8sClient.Create(context.Background(), ns); err != nil {
+		t.Fatalf("ошибка создания пространства имён: %v", err)
+	}
+	return ns.Name
+}
+
+// staticAPI возвращает один и тот же API Nexus для всех ресурсов.
+type staticAPI struct {
+	api nexus.API
+}
+
+func (s staticAPI) APIFor(context.Context, string, *nexusv1alpha1.InstanceReference) (nexus.API, error) {
+	return s.api, nil
+}
+
+// newFakeNexus запускает fake-сервер Nexus на время теста и создаёт клиент с короткими паузами
+// между повторами.
+func newFakeNexus(t *testing.T, opts ...fake.Option) (*fake.Server, *nexus.Client) {
+	t.Helper()
+
+	srv := fake.NewServer(opts...)
+	t.Cleanup(srv.Close)
+	c, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("ошибка создания клиента Nexus: %v", err)
+	}
+	c.Resty.SetRetryWaitTime(time.Millisecond).SetRetryMaxWaitTime(5 * time.Millisecond)
+	return srv, c
+}
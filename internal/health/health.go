@@ -0,0 +1,345 @@
+// Проверки работоспособности и готовности оператора
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mkostelcev/nexus-operator/internal/controller"
+)
+
+const (
+	defaultNexusCheckTTL     = 30 * time.Second
+	defaultCheckTimeout      = 5 * time.Second
+	defaultHeartbeatInterval = 10 * time.Second
+	defaultHeartbeatTimeout  = time.Minute
+	defaultReconcileTimeout  = 15 * time.Minute
+
+	cacheSyncCheck = "cache-sync"
+	instancesCheck = "instances"
+	nexusCheck     = "nexus/"
+)
+
+var (
+	errCacheNotSynced   = errors.New("кэш контроллеров не синхронизирован")
+	errNotReady         = errors.New("оператор не готов")
+	errHeartbeatStalled = errors.New("менеджер не отвечает")
+	errReconcileStalled = errors.New("контроллер не обрабатывает ресурсы")
+)
+
+// CacheSyncer - кэш контроллеров, например mgr.GetCache().
+type CacheSyncer interface {
+	WaitForCacheSync(ctx context.Context) bool
+}
+
+// CheckResult - результат отдельной проверки.
+type CheckResult struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	// Informational - проверка выводится в отчёте, но не влияет на готовность оператора.
+	Informational bool      `json:"informational,omitempty"`
+	Error         string    `json:"error,omitempty"`
+	CheckedAt     time.Time `json:"checkedAt"`
+}
+
+// Report - результат всех проверок готовности.
+type Report struct {
+	Ready  bool          `json:"ready"`
+	Checks []CheckResult `json:"checks"`
+}
+
+// Readiness проверяет синхронизацию кэша контроллеров и доступность экземпляра Nexus по умолчанию.
+// Экземпляры NexusInstance и ClusterNexusInstance принадлежат пользователям, поэтому их состояние
+// выводится только в подробном отчёте и не влияет на готовность. Результаты проверок Nexus кэшируются на TTL.
+type Readiness struct {
+	Cache     CacheSyncer
+	Instances *controller.InstanceResolver
+	TTL       time.Duration
+	Timeout   time.Duration
+
+	mu      sync.Mutex
+	results map[string]CheckResult
+}
+
+// NewReadiness создаёт проверку готовности с параметрами по умолчанию.
+func NewReadiness(cache CacheSyncer, instances *controller.InstanceResolver) *Readiness {
+	return &Readiness{
+		Cache:     cache,
+		Instances: instances,
+		TTL:       defaultNexusCheckTTL,
+		Timeout:   defaultCheckTimeout,
+		results:   make(map[string]CheckResult),
+	}
+}
+
+// Check выполняет проверки готовности. При detail=true в отчёт добавляются
+// информационные проверки экземпляров NexusInstance и ClusterNexusInstance.
+func (r *Readiness) Check(ctx context.Context, detail bool) Report {
+	ctx, cancel := context.WithTimeout(ctx, r.Timeout)
+	defer cancel()
+
+	report := Report{Ready: true}
+	add := func(result CheckResult) {
+		report.Ready = report.Ready && (result.Healthy || result.Informational)
+		report.Checks = append(report.Checks, result)
+	}
+
+	add(r.checkCacheSync(ctx))
+	if !report.Ready {
+		// Без синхронизированного кэша список экземпляров недоступен.
+		return report
+	}
+
+	if ic, ok := r.Instances.DefaultInstanceClient(ctx); ok {
+		add(r.checkNexus(ctx, ic))
+	}
+	if !detail {
+		return report
+	}
+
+	// Экземпляры пользователей, в том числе ошибка получения их списка, на готовность не влияют.
+	clients, err := r.Instances.InstanceClients(ctx)
+	if err != nil {
+		result := newResult(instancesCheck, err)
+		result.Informational = true
+		add(result)
+		return report
+	}
+	for _, ic := range clients {
+		result := r.checkNexus(ctx, ic)
+		result.Informational = true
+		add(result)
+	}
+	return report
+}
+
+// Checker возвращает проверку в формате healthz.Checker.
+func (r *Readiness) Checker(req *http.Request) error {
+	report := r.Check(req.Context(), false)
+	if report.Ready {
+		return nil
+	}
+	for _, check := range report.Checks {
+		if !check.Healthy && !check.Informational {
+			return fmt.Errorf("%w: %s: %s", errNotReady, check.Name, check.Error)
+		}
+	}
+	return errNotReady
+}
+
+// Handler отвечает 200 OK, если оператор готов, и 503 в противном случае.
+// При detail=true в ответ выводится JSON с результатом каждой проверки.
+func (r *Readiness) Handler(detail bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		report := r.Check(req.Context(), detail)
+		status := http.StatusOK
+		if !report.Ready {
+			status = http.StatusServiceUnavailable
+		}
+
+		if !detail {
+			w.WriteHeader(status)
+			if report.Ready {
+				_, _ = w.Write([]byte("OK"))
+			} else {
+				_, _ = w.Write([]byte("NOT READY"))
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(report)
+	}
+}
+
+func (r *Readiness) checkCacheSync(ctx context.Context) CheckResult {
+	if !r.Cache.WaitForCacheSync(ctx) {
+		return newResult(cacheSyncCheck, errCacheNotSynced)
+	}
+	return newResult(cacheSyncCheck, nil)
+}
+
+// checkNexus проверяет экземпляр через /service/rest/v1/status и /status/writable,
+// используя закэшированный результат, если он не старше TTL.
+func (r *Readiness) checkNexus(ctx context.Context, ic controller.InstanceClient) CheckResult {
+	name := nexusCheck + ic.Key
+	if ic.Err != nil {
+		return newResult(name, ic.Err)
+	}
+
+	r.mu.Lock()
+	cached, ok := r.results[name]
+	r.mu.Unlock()
+	if ok && time.Since(cached.CheckedAt) < r.TTL {
+		return cached
+	}
+
+	result := newResult(name, ic.Client.CheckStatus(ctx))
+
+	r.mu.Lock()
+	r.results[name] = result
+	r.mu.Unlock()
+	return result
+}
+
+func newResult(name string, err error) CheckResult {
+	result := CheckResult{Name: name, Healthy: err == nil, CheckedAt: time.Now()}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Heartbeat отслеживает, что менеджер запущен и его горутины выполняются.
+// Запускается как Runnable менеджера; проверка живости не проходит, если
+// отметка не обновлялась дольше Timeout.
+//
+// Кроме того, Heartbeat реализует controller.ReconcileTracker: проверка живости не проходит,
+// если у контроллера есть необработанные ресурсы, а последняя успешная обработка была
+// дольше ReconcileTimeout назад. Ошибки Nexus контроллеры записывают в статус ресурса, в том числе
+// при его удалении, и повторяют обработку с задержкой, поэтому недоступность Nexus не приводит
+// к перезапуску оператора.
+type Heartbeat struct {
+	Interval         time.Duration
+	Timeout          time.Duration
+	ReconcileTimeout time.Duration
+
+	last atomic.Int64
+
+	mu          sync.Mutex
+	controllers map[string]*reconcileProgress
+}
+
+// reconcileProgress - отметки обработки ресурсов контроллером.
+type reconcileProgress struct {
+	// lastSuccess - время последней обработки ресурса без ошибки.
+	lastSuccess time.Time
+	// pendingSince - время начала первой обработки после lastSuccess, которая ещё не завершилась успешно.
+	pendingSince time.Time
+}
+
+// NewHeartbeat создаёт Heartbeat с параметрами по умолчанию.
+func NewHeartbeat() *Heartbeat {
+	h := &Heartbeat{
+		Interval:         defaultHeartbeatInterval,
+		Timeout:          defaultHeartbeatTimeout,
+		ReconcileTimeout: defaultReconcileTimeout,
+		controllers:      make(map[string]*reconcileProgress),
+	}
+	h.beat()
+	return h
+}
+
+// ReconcileStarted отмечает начало обработки ресурса контроллером.
+func (h *Heartbeat) ReconcileStarted(controller string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	progress := h.progress(controller)
+	if progress.pendingSince.IsZero() {
+		progress.pendingSince = time.Now()
+	}
+}
+
+// ReconcileFinished отмечает завершение обработки ресурса контроллером.
+// Обработка с ошибкой будет повторена, поэтому ресурс остаётся необработанным.
+func (h *Heartbeat) ReconcileFinished(controller string, err error) {
+	if err != nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	progress := h.progress(controller)
+	progress.lastSuccess = time.Now()
+	progress.pendingSince = time.Time{}
+}
+
+func (h *Heartbeat) progress(controller string) *reconcileProgress {
+	progress, ok := h.controllers[controller]
+	if !ok {
+		progress = &reconcileProgress{}
+		h.controllers[controller] = progress
+	}
+	return progress
+}
+
+// Start обновляет отметку до остановки менеджера.
+func (h *Heartbeat) Start(ctx context.Context) error {
+	ticker := time.NewTicker(h.Interval)
+	defer ticker.Stop()
+
+	for {
+		h.beat()
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection возвращает false: проверка живости нужна и на резервных репликах.
+func (h *Heartbeat) NeedLeaderElection() bool {
+	return false
+}
+
+// Checker возвращает проверку в формате healthz.Checker.
+func (h *Heartbeat) Checker(_ *http.Request) error {
+	if age := time.Since(time.Unix(0, h.last.Load())); age > h.Timeout {
+		return fmt.Errorf("%w: последняя отметка %s назад", errHeartbeatStalled, age.Round(time.Second))
+	}
+	return h.checkControllers()
+}
+
+// checkControllers возвращает ошибку для первого по имени контроллера, который дольше
+// ReconcileTimeout не может успешно обработать ни один ресурс.
+func (h *Heartbeat) checkControllers() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	names := make([]string, 0, len(h.controllers))
+	for name := range h.controllers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		progress := h.controllers[name]
+		if progress.pendingSince.IsZero() {
+			continue
+		}
+		if age := time.Since(progress.pendingSince); age > h.ReconcileTimeout {
+			last := "никогда"
+			if !progress.lastSuccess.IsZero() {
+				last = time.Since(progress.lastSuccess).Round(time.Second).String() + " назад"
+			}
+			return fmt.Errorf("%w: %s, последняя успешная обработка: %s", errReconcileStalled, name, last)
+		}
+	}
+	return nil
+}
+
+// Handler отвечает 200 OK, пока менеджер работает, и 503 в противном случае.
+func (h *Heartbeat) Handler(w http.ResponseWriter, req *http.Request) {
+	if err := h.Checker(req); err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("OK"))
+}
+
+func (h *Heartbeat) beat() {
+	h.last.Store(time.Now().UnixNano())
+}
@@ -0,0 +1,66 @@
+package health
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHeartbeatReconcileProgress(t *testing.T) {
+	const timeout = 10 * time.Millisecond
+	errReconcile := errors.New("ошибка обработки")
+
+	tests := []struct {
+		name    string
+		run     func(h *Heartbeat)
+		stalled bool
+	}{
+		{
+			name: "успешная обработка",
+			run: func(h *Heartbeat) {
+				h.ReconcileStarted("Repository")
+				h.ReconcileFinished("Repository", nil)
+			},
+		},
+		{
+			// Контроллеры записывают ошибки Nexus в статус и завершают обработку без ошибки,
+			// поэтому отложенное удаление при недоступном Nexus не останавливает контроллер.
+			name: "повтор с задержкой",
+			run: func(h *Heartbeat) {
+				h.ReconcileStarted("Repository")
+				h.ReconcileFinished("Repository", errReconcile)
+				h.ReconcileStarted("Repository")
+				h.ReconcileFinished("Repository", nil)
+			},
+		},
+		{
+			name: "обработка завершается с ошибкой",
+			run: func(h *Heartbeat) {
+				h.ReconcileStarted("Repository")
+				h.ReconcileFinished("Repository", errReconcile)
+			},
+			stalled: true,
+		},
+		{
+			name: "обработка не завершается",
+			run: func(h *Heartbeat) {
+				h.ReconcileStarted("BlobStore")
+			},
+			stalled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHeartbeat()
+			h.ReconcileTimeout = timeout
+			tt.run(h)
+			time.Sleep(2 * timeout)
+
+			err := h.Checker(nil)
+			if stalled := errors.Is(err, errReconcileStalled); stalled != tt.stalled {
+				t.Errorf("Checker: %v, ожидалась остановка контроллера: %t", err, tt.stalled)
+			}
+		})
+	}
+}
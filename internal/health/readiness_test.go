@@ -0,0 +1,87 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	nexusv1alpha1 "github.com/mkostelcev/nexus-operator/api/v1alpha1"
+	"github.com/mkostelcev/nexus-operator/internal/controller"
+	"github.com/mkostelcev/nexus-operator/pkg/nexus/fake"
+)
+
+type syncedCache struct{}
+
+func (syncedCache) WaitForCacheSync(context.Context) bool { return true }
+
+// TestReadinessIgnoresUserInstances проверяет, что готовность зависит только от экземпляра по умолчанию:
+// экземпляры пользователей не запрашиваются при обычной проверке, а ошибка получения их списка
+// выводится в подробном отчёте как информационная.
+func TestReadinessIgnoresUserInstances(t *testing.T) {
+	srv := fake.NewServer()
+	t.Cleanup(srv.Close)
+	cfg := srv.Config()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := nexusv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "nexus-credentials", Namespace: "nexus-operator"},
+		Data:       map[string][]byte{"username": []byte(cfg.Username), "password": []byte(cfg.Password)},
+	}
+	errList := errors.New("список экземпляров недоступен")
+	lists := 0
+	reader := fakeclient.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(secret).
+		WithInterceptorFuncs(interceptor.Funcs{
+			List: func(context.Context, client.WithWatch, client.ObjectList, ...client.ListOption) error {
+				lists++
+				return errList
+			},
+		}).
+		Build()
+
+	instances := controller.NewInstanceResolver(reader)
+	instances.DefaultInstance = &nexusv1alpha1.NexusInstanceSpec{
+		URL: cfg.BaseURL,
+		CredentialsSecretRef: nexusv1alpha1.CredentialsSecretReference{
+			ObjectReference: nexusv1alpha1.ObjectReference{Name: secret.Name, Namespace: secret.Namespace},
+		},
+	}
+	readiness := NewReadiness(syncedCache{}, instances)
+
+	report := readiness.Check(context.Background(), false)
+	if !report.Ready {
+		t.Fatalf("оператор не готов: %+v", report.Checks)
+	}
+	if lists != 0 {
+		t.Errorf("обычная проверка запросила список экземпляров %d раз", lists)
+	}
+
+	report = readiness.Check(context.Background(), true)
+	if !report.Ready {
+		t.Fatalf("ошибка списка экземпляров повлияла на готовность: %+v", report.Checks)
+	}
+	var found bool
+	for _, check := range report.Checks {
+		if check.Name == instancesCheck {
+			found = !check.Healthy && check.Informational
+		}
+	}
+	if !found {
+		t.Errorf("в подробном отчёте нет информационной ошибки списка экземпляров: %+v", report.Checks)
+	}
+}
@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"errors"
 	"flag"
 	"fmt"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -14,13 +17,13 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	nexusv1alpha1 "github.com/mkostelcev/nexus-operator/api/v1alpha1"
 	"github.com/mkostelcev/nexus-operator/internal/controller"
+	"github.com/mkostelcev/nexus-operator/internal/health"
 	"github.com/mkostelcev/nexus-operator/pkg/nexus"
 )
 
@@ -35,6 +38,7 @@ var (
 	appName   = "nexus-operator-kostoed"
 
 	errMissingEnvVar = nexus.ErrMissingEnvVars
+	errInvalidEnvVar = errors.New("некорректное значение ENV-переменной")
 )
 
 func init() {
@@ -44,12 +48,14 @@ func init() {
 
 func main() {
 	var (
-		metricsAddr          string
-		enableLeaderElection bool
-		probeAddr            string
-		secureMetrics        bool
-		enableHTTP2          bool
-		devMode              bool
+		metricsAddr             string
+		enableLeaderElection    bool
+		probeAddr               string
+		secureMetrics           bool
+		enableHTTP2             bool
+		devMode                 bool
+		dryRun                  bool
+		forbidInlineCredentials bool
 	)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8081", "Metrics bind address")
@@ -58,6 +64,10 @@ func main() {
 	flag.BoolVar(&secureMetrics, "metrics-secure", false, "Secure metrics serving")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false, "Enable HTTP/2")
 	flag.BoolVar(&devMode, "dev", false, "Development mode")
+	flag.BoolVar(&dryRun, "dry-run", false, "Log changes instead of applying them to Nexus")
+	flag.BoolVar(&forbidInlineCredentials, "forbid-inline-credentials", false,
+		"Do not reconcile repositories with upstream passwords or tokens set inline instead of in Secrets; "+
+			"enable config/policy to reject them at admission")
 
 	opts := zap.Options{
 		Development: devMode,
@@ -75,8 +85,11 @@ func main() {
 		"startTime", startTime.Format(time.RFC3339),
 	)
 
+	// ENV-переменные задают экземпляр Nexus по умолчанию и необязательны,
+	// если все ресурсы ссылаются на NexusInstance или ClusterNexusInstance.
 	if err := checkEnvVars(); err != nil {
-		handleCriticalError(err, "Ошибка проверки ENV-переменных")
+		setupLog.Info("Экземпляр Nexus по умолчанию не настроен, ресурсы без instanceRef не будут синхронизированы",
+			"reason", err.Error())
 	}
 
 	// Настройка TLS
@@ -95,9 +108,6 @@ func main() {
 		},
 	}
 
-	// Запуск кастомного health-сервера
-	go startHealthServer(probeAddr)
-
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
 		Metrics: metricsserver.Options{
@@ -116,14 +126,43 @@ func main() {
 		handleCriticalError(err, "Ошибка инициализации Manager")
 	}
 
-	if err := initControllers(mgr); err != nil {
+	if err := controller.SetupInstanceIndexes(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		handleCriticalError(err, "Ошибка создания индексов экземпляров Nexus")
+	}
+	instances := controller.NewInstanceResolver(mgr.GetClient())
+	defaultSpec, err := defaultInstance()
+	if err != nil {
+		handleCriticalError(err, "Ошибка настройки экземпляра Nexus по умолчанию")
+	}
+	instances.DefaultInstance = defaultSpec
+	if instances.DefaultDockerPorts, err = defaultDockerPorts(); err != nil {
+		handleCriticalError(err, "Ошибка настройки экземпляра Nexus по умолчанию")
+	}
+	if dryRun {
+		setupLog.Info("Включён режим dry-run: изменения в Nexus не применяются")
+		instances.Decorators = append(instances.Decorators, nexus.DryRun(mgr.GetLogger().WithName("nexus")))
+	}
+
+	// Heartbeat получает отметки об обработке ресурсов от всех контроллеров.
+	heartbeat := health.NewHeartbeat()
+	controller.SetReconcileTracker(heartbeat)
+
+	if err := initControllers(mgr, instances, dryRun, forbidInlineCredentials); err != nil {
 		handleCriticalError(err, "Ошибка инициализации контроллеров")
 	}
 
-	if err := setupHealthChecks(mgr); err != nil {
+	readiness := health.NewReadiness(mgr.GetCache(), instances)
+	if err := mgr.Add(heartbeat); err != nil {
+		handleCriticalError(err, "Ошибка инициализации Heartbeat")
+	}
+
+	if err := setupHealthChecks(mgr, readiness, heartbeat); err != nil {
 		handleCriticalError(err, "Ошибка инициализации Health-Checks")
 	}
 
+	// Запуск кастомного health-сервера
+	go startHealthServer(probeAddr, readiness, heartbeat)
+
 	setupLog.Info("Запуск менеджера")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		handleCriticalError(err, "Ошибка запуска менеджера")
@@ -132,9 +171,12 @@ func main() {
 
 func checkEnvVars() error {
 	required := map[string]string{
-		"NEXUS_URL":      "URL Nexus",
-		"NEXUS_USER":     "Пользователь Nexus",
-		"NEXUS_PASSWORD": "Пароль Nexus",
+		"NEXUS_URL": "URL Nexus",
+	}
+	// Учётные данные берутся либо из Secret, либо из ENV-переменных
+	if os.Getenv("NEXUS_CREDENTIALS_SECRET") == "" {
+		required["NEXUS_USER"] = "Пользователь Nexus"
+		required["NEXUS_PASSWORD"] = "Пароль Nexus"
 	}
 
 	var missing []string
@@ -150,17 +192,63 @@ func checkEnvVars() error {
 	return nil
 }
 
-// Новый метод для запуска health-сервера
-func startHealthServer(address string) {
+// defaultInstance возвращает экземпляр Nexus по умолчанию, если его учётные данные
+// заданы Secret-ом в ENV-переменной NEXUS_CREDENTIALS_SECRET (формат namespace/name).
+func defaultInstance() (*nexusv1alpha1.NexusInstanceSpec, error) {
+	secretRef := os.Getenv("NEXUS_CREDENTIALS_SECRET")
+	if secretRef == "" {
+		return nil, nil
+	}
+
+	namespace, name, ok := strings.Cut(secretRef, "/")
+	if !ok || namespace == "" || name == "" {
+		return nil, fmt.Errorf("%w: NEXUS_CREDENTIALS_SECRET должна иметь формат namespace/name", errInvalidEnvVar)
+	}
+
+	return &nexusv1alpha1.NexusInstanceSpec{
+		URL: os.Getenv("NEXUS_URL"),
+		CredentialsSecretRef: nexusv1alpha1.CredentialsSecretReference{
+			ObjectReference: nexusv1alpha1.ObjectReference{
+				Name:      name,
+				Namespace: namespace,
+			},
+		},
+	}, nil
+}
+
+// defaultDockerPorts возвращает диапазон портов коннекторов Docker для экземпляра по умолчанию
+// из ENV-переменной NEXUS_DOCKER_PORTS (формат from-to, например 5000-5099).
+func defaultDockerPorts() (*nexusv1alpha1.PortRange, error) {
+	value := os.Getenv("NEXUS_DOCKER_PORTS")
+	if value == "" {
+		return nil, nil
+	}
+
+	errFormat := fmt.Errorf("%w: NEXUS_DOCKER_PORTS должна иметь формат from-to с портами от 1 до 65535", errInvalidEnvVar)
+	fromValue, toValue, ok := strings.Cut(value, "-")
+	if !ok {
+		return nil, errFormat
+	}
+	from, err := strconv.ParseInt(fromValue, 10, 32)
+	if err != nil {
+		return nil, errFormat
+	}
+	to, err := strconv.ParseInt(toValue, 10, 32)
+	if err != nil || from < 1 || to > 65535 || from > to {
+		return nil, errFormat
+	}
+	return &nexusv1alpha1.PortRange{From: int32(from), To: int32(to)}, nil
+}
+
+// startHealthServer запускает health-сервер:
+// /health/liveness - менеджер работает и контроллеры обрабатывают ресурсы,
+// /health/readiness - кэш синхронизирован и экземпляр Nexus по умолчанию доступен на запись,
+// /health/readiness/detail - JSON с результатом каждой проверки, включая NexusInstance и ClusterNexusInstance.
+func startHealthServer(address string, readiness *health.Readiness, heartbeat *health.Heartbeat) {
 	router := http.NewServeMux()
-	router.HandleFunc("/health/liveness", func(w http.ResponseWriter, _ *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("OK"))
-	})
-	router.HandleFunc("/health/readiness", func(w http.ResponseWriter, _ *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("OK"))
-	})
+	router.HandleFunc("/health/liveness", heartbeat.Handler)
+	router.HandleFunc("/health/readiness", readiness.Handler(false))
+	router.HandleFunc("/health/readiness/detail", readiness.Handler(true))
 
 	server := &http.Server{
 		Addr:              address,
@@ -177,18 +265,48 @@ func startHealthServer(address string) {
 	}
 }
 
-func initControllers(mgr ctrl.Manager) error {
+func initControllers(
+	mgr ctrl.Manager,
+	instances *controller.InstanceResolver,
+	dryRun, forbidInlineCredentials bool,
+) error {
 	controllers := []struct {
 		name string
 		init func() error
 	}{
+		{
+			name: "NexusInstance",
+			init: func() error {
+				return (&controller.NexusInstanceReconciler{
+					Client:    mgr.GetClient(),
+					Scheme:    mgr.GetScheme(),
+					Log:       mgr.GetLogger().WithValues("controller", "NexusInstance"),
+					Instances: instances,
+				}).SetupWithManager(mgr)
+			},
+		},
+		{
+			name: "ClusterNexusInstance",
+			init: func() error {
+				return (&controller.ClusterNexusInstanceReconciler{
+					Client:    mgr.GetClient(),
+					Scheme:    mgr.GetScheme(),
+					Log:       mgr.GetLogger().WithValues("controller", "ClusterNexusInstance"),
+					Instances: instances,
+				}).SetupWithManager(mgr)
+			},
+		},
 		{
 			name: "Repository",
 			init: func() error {
 				return (&controller.RepositoryReconciler{
-					Client: mgr.GetClient(),
-					Scheme: mgr.GetScheme(),
-					Log:    mgr.GetLogger().WithValues("controller", "Repository"),
+					Client:                  mgr.GetClient(),
+					Scheme:                  mgr.GetScheme(),
+					Log:                     mgr.GetLogger().WithValues("controller", "Repository"),
+					Nexus:                   instances,
+					Instances:               instances,
+					DryRun:                  dryRun,
+					ForbidInlineCredentials: forbidInlineCredentials,
 				}).SetupWithManager(mgr)
 			},
 		},
@@ -196,9 +314,11 @@ func initControllers(mgr ctrl.Manager) error {
 			name: "ContentSelector",
 			init: func() error {
 				return (&controller.ContentSelectorReconciler{
-					Client: mgr.GetClient(),
-					Scheme: mgr.GetScheme(),
-					Log:    mgr.GetLogger().WithValues("controller", "ContentSelector"),
+					Client:    mgr.GetClient(),
+					Scheme:    mgr.GetScheme(),
+					Log:       mgr.GetLogger().WithValues("controller", "ContentSelector"),
+					Nexus:     instances,
+					Instances: instances,
 				}).SetupWithManager(mgr)
 			},
 		},
@@ -206,9 +326,11 @@ func initControllers(mgr ctrl.Manager) error {
 			name: "Privilege",
 			init: func() error {
 				return (&controller.PrivilegeReconciler{
-					Client: mgr.GetClient(),
-					Scheme: mgr.GetScheme(),
-					Log:    mgr.GetLogger().WithValues("controller", "Privilege"),
+					Client:    mgr.GetClient(),
+					Scheme:    mgr.GetScheme(),
+					Log:       mgr.GetLogger().WithValues("controller", "Privilege"),
+					Nexus:     instances,
+					Instances: instances,
 				}).SetupWithManager(mgr)
 			},
 		},
@@ -216,9 +338,47 @@ func initControllers(mgr ctrl.Manager) error {
 			name: "Role",
 			init: func() error {
 				return (&controller.RoleReconciler{
-					Client: mgr.GetClient(),
-					Scheme: mgr.GetScheme(),
-					Log:    mgr.GetLogger().WithValues("controller", "Role"),
+					Client:    mgr.GetClient(),
+					Scheme:    mgr.GetScheme(),
+					Log:       mgr.GetLogger().WithValues("controller", "Role"),
+					Nexus:     instances,
+					Instances: instances,
+				}).SetupWithManager(mgr)
+			},
+		},
+		{
+			name: "BlobStore",
+			init: func() error {
+				return (&controller.BlobStoreReconciler{
+					Client:    mgr.GetClient(),
+					Scheme:    mgr.GetScheme(),
+					Log:       mgr.GetLogger().WithValues("controller", "BlobStore"),
+					Nexus:     instances,
+					Instances: instances,
+				}).SetupWithManager(mgr)
+			},
+		},
+		{
+			name: "RoutingRule",
+			init: func() error {
+				return (&controller.RoutingRuleReconciler{
+					Client:    mgr.GetClient(),
+					Scheme:    mgr.GetScheme(),
+					Log:       mgr.GetLogger().WithValues("controller", "RoutingRule"),
+					Nexus:     instances,
+					Instances: instances,
+				}).SetupWithManager(mgr)
+			},
+		},
+		{
+			name: "CleanupPolicy",
+			init: func() error {
+				return (&controller.CleanupPolicyReconciler{
+					Client:    mgr.GetClient(),
+					Scheme:    mgr.GetScheme(),
+					Log:       mgr.GetLogger().WithValues("controller", "CleanupPolicy"),
+					Nexus:     instances,
+					Instances: instances,
 				}).SetupWithManager(mgr)
 			},
 		},
@@ -242,12 +402,12 @@ func initControllers(mgr ctrl.Manager) error {
 	return nil
 }
 
-func setupHealthChecks(mgr ctrl.Manager) error {
-	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+func setupHealthChecks(mgr ctrl.Manager, readiness *health.Readiness, heartbeat *health.Heartbeat) error {
+	if err := mgr.AddHealthzCheck("healthz", heartbeat.Checker); err != nil {
 		return fmt.Errorf("healthz check: %w", err)
 	}
 
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+	if err := mgr.AddReadyzCheck("readyz", readiness.Checker); err != nil {
 		return fmt.Errorf("readyz check: %w", err)
 	}
 
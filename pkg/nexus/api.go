@@ -0,0 +1,107 @@
+// Интерфейс API Sonatype Nexus
+package nexus
+
+import (
+	"context"
+)
+
+// API описывает все операции клиента Nexus. Контроллеры работают с этим интерфейсом,
+// что позволяет подменять реализацию и оборачивать её декораторами.
+type API interface {
+	ServerAPI
+	RepositoryAPI
+	RoleAPI
+	PrivilegeAPI
+	ContentSelectorAPI
+	CleanupPolicyAPI
+	BlobStoreAPI
+	RoutingRuleAPI
+}
+
+// ServerAPI - состояние экземпляра Nexus.
+type ServerAPI interface {
+	// Available возвращает ErrNexusUnavailable, пока обращения к Nexus приостановлены.
+	Available() error
+	ServerInfo(ctx context.Context) (*ServerInfo, error)
+	CheckStatus(ctx context.Context) error
+}
+
+// RepositoryAPI - операции с репозиториями.
+type RepositoryAPI interface {
+	GetRepository(ctx context.Context, repoType, name string) (*Repository, error)
+	GetRepositorySummary(ctx context.Context, name string) (*Repository, error)
+	RepositoryExists(ctx context.Context, name string) (bool, error)
+	CreateRepository(ctx context.Context, repoType string, repo *Repository) error
+	UpdateRepository(ctx context.Context, repoType string, repo *Repository) error
+	DeleteRepository(ctx context.Context, name string) error
+}
+
+// RoleAPI - операции с ролями.
+type RoleAPI interface {
+	GetRole(ctx context.Context, roleID string) (*Role, error)
+	RoleExists(ctx context.Context, roleID string) (bool, error)
+	CreateRole(ctx context.Context, role Role) error
+	UpdateRole(ctx context.Context, roleID string, role Role) error
+	DeleteRole(ctx context.Context, roleID string) error
+}
+
+// PrivilegeAPI - операции с привилегиями.
+type PrivilegeAPI interface {
+	GetPrivilege(ctx context.Context, name string) (*Privilege, error)
+	PrivilegeExists(ctx context.Context, name string) (bool, error)
+	CreatePrivilege(ctx context.Context, config *Privilege) error
+	UpdatePrivilege(ctx context.Context, name string, config *Privilege) error
+	DeletePrivilege(ctx context.Context, name string) error
+}
+
+// ContentSelectorAPI - операции с content-selectors.
+type ContentSelectorAPI interface {
+	GetContentSelector(ctx context.Context, name string) (*ContentSelectorResponse, error)
+	ContentSelectorExists(ctx context.Context, name string) (bool, error)
+	CreateContentSelector(ctx context.Context, name, description, expression string) error
+	UpdateContentSelector(ctx context.Context, name, description, expression string) error
+	DeleteContentSelector(ctx context.Context, name string) error
+}
+
+// CleanupPolicyAPI - операции с политиками очистки.
+type CleanupPolicyAPI interface {
+	GetCleanupPolicy(ctx context.Context, name string) (*CleanupPolicy, error)
+	CleanupPolicyExists(ctx context.Context, name string) (bool, error)
+	CreateCleanupPolicy(ctx context.Context, policy *CleanupPolicy) error
+	UpdateCleanupPolicy(ctx context.Context, name string, policy *CleanupPolicy) error
+	DeleteCleanupPolicy(ctx context.Context, name string) error
+}
+
+// BlobStoreAPI - операции с хранилищами blob-объектов.
+type BlobStoreAPI interface {
+	GetBlobStore(ctx context.Context, storeType, name string) (*BlobStore, error)
+	BlobStoreExists(ctx context.Context, name string) (bool, error)
+	CreateBlobStore(ctx context.Context, store *BlobStore) error
+	UpdateBlobStore(ctx context.Context, name string, store *BlobStore) error
+	DeleteBlobStore(ctx context.Context, name string) error
+}
+
+// RoutingRuleAPI - операции с правилами маршрутизации.
+type RoutingRuleAPI interface {
+	GetRoutingRule(ctx context.Context, name string) (*RoutingRule, error)
+	RoutingRuleExists(ctx context.Context, name string) (bool, error)
+	CreateRoutingRule(ctx context.Context, rule *RoutingRule) error
+	UpdateRoutingRule(ctx context.Context, name string, rule *RoutingRule) error
+	DeleteRoutingRule(ctx context.Context, name string) error
+}
+
+var _ API = (*Client)(nil)
+
+// Decorator оборачивает API дополнительным поведением: метриками, кэшированием,
+// dry-run и т.п. Декоратор может встроить API в структуру и переопределить
+// только нужные методы.
+type Decorator func(API) API
+
+// Decorate применяет декораторы по порядку: первый оборачивает api непосредственно,
+// последний оказывается внешним.
+func Decorate(api API, decorators ...Decorator) API {
+	for _, decorate := range decorators {
+		api = decorate(api)
+	}
+	return api
+}
@@ -0,0 +1,266 @@
+// Работа с хранилищами blob-объектов в Sonatype Nexus
+package nexus
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/mkostelcev/nexus-operator/api/v1alpha1"
+)
+
+const (
+	blobStoresAPIPath = "/service/rest/v1/blobstores"
+
+	BlobStoreTypeFile = "File"
+	BlobStoreTypeS3   = "S3"
+
+	// bytesPerMegabyte - Nexus задаёт предел мягкой квоты в мегабайтах по 10^6 байт.
+	bytesPerMegabyte = 1000 * 1000
+	// defaultS3Expiration - срок хранения удалённых объектов в бакете по умолчанию, как в Nexus.
+	defaultS3Expiration = 3
+)
+
+// blobStoreEndpoint возвращает путь API для типа хранилища.
+func blobStoreEndpoint(storeType string) (string, error) {
+	switch storeType {
+	case BlobStoreTypeFile, BlobStoreTypeS3:
+		return blobStoresAPIPath + "/" + strings.ToLower(storeType), nil
+	default:
+		return "", fmt.Errorf("%w: неподдерживаемый тип %q", ErrInvalidBlobStoreSpec, storeType)
+	}
+}
+
+// BlobStoreExists проверяет, существует ли хранилище с указанным именем любого типа.
+func (c *Client) BlobStoreExists(ctx context.Context, name string) (bool, error) {
+	var stores []BlobStore
+	resp, err := c.Resty.R().
+		SetContext(ctx).
+		SetResult(&stores).
+		Get(blobStoresAPIPath)
+	if err != nil {
+		return false, fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+	if resp.StatusCode() != 200 {
+		return false, NewAPIError(resp)
+	}
+
+	for _, store := range stores {
+		if store.Name == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetBlobStore получает конфигурацию хранилища указанного типа.
+func (c *Client) GetBlobStore(ctx context.Context, storeType, name string) (*BlobStore, error) {
+	endpoint, err := blobStoreEndpoint(storeType)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Resty.R().
+		SetContext(ctx).
+		SetPathParam("name", name).
+		SetResult(&BlobStore{}).
+		Get(endpoint + "/{name}")
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		store := resp.Result().(*BlobStore)
+		// Nexus не всегда возвращает имя и тип хранилища.
+		store.Type, store.Name = storeType, name
+		return store, nil
+	case 404:
+		return nil, ErrBlobStoreNotFound
+	default:
+		return nil, NewAPIError(resp)
+	}
+}
+
+// CreateBlobStore создаёт хранилище.
+func (c *Client) CreateBlobStore(ctx context.Context, store *BlobStore) error {
+	endpoint, err := blobStoreEndpoint(store.Type)
+	if err != nil {
+		return err
+	}
+
+	c.Logger.Infof("Создание хранилища blob-объектов %s типа %s", store.Name, store.Type)
+	resp, err := c.Resty.R().
+		SetContext(ctx).
+		SetBody(store).
+		SetHeader("Content-Type", "application/json").
+		Post(endpoint)
+	if err != nil {
+		return fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+
+	if resp.StatusCode() >= 200 && resp.StatusCode() < 300 {
+		return nil
+	}
+	return NewAPIError(resp)
+}
+
+// UpdateBlobStore обновляет существующее хранилище.
+func (c *Client) UpdateBlobStore(ctx context.Context, name string, store *BlobStore) error {
+	endpoint, err := blobStoreEndpoint(store.Type)
+	if err != nil {
+		return err
+	}
+
+	c.Logger.Infof("Обновление хранилища blob-объектов %s", name)
+	resp, err := c.Resty.R().
+		SetContext(ctx).
+		SetPathParam("name", name).
+		SetBody(store).
+		SetHeader("Content-Type", "application/json").
+		Put(endpoint + "/{name}")
+	if err != nil {
+		return fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+
+	if resp.StatusCode() >= 200 && resp.StatusCode() < 300 {
+		return nil
+	}
+	return NewAPIError(resp)
+}
+
+// DeleteBlobStore удаляет хранилище. Nexus не удаляет хранилище, которое используют репозитории.
+func (c *Client) DeleteBlobStore(ctx context.Context, name string) error {
+	resp, err := c.Resty.R().
+		SetContext(ctx).
+		SetPathParam("name", name).
+		Delete(blobStoresAPIPath + "/{name}")
+	if err != nil {
+		return fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+
+	switch resp.StatusCode() {
+	case 200, 204:
+		return nil
+	case 404:
+		return ErrBlobStoreNotFound
+	default:
+		return NewAPIError(resp)
+	}
+}
+
+// S3Credentials - ключ доступа к бакету S3 из Secret.
+type S3Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// Hash возвращает хэш ключа, по которому отслеживается его изменение.
+// Сам ключ в статус и журнал не попадает.
+func (k *S3Credentials) Hash() string {
+	if k == nil {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(k.AccessKeyID + "\x00" + k.SecretAccessKey))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// BuildBlobStoreConfig создаёт конфигурацию хранилища. credentials задаются только для S3
+// со ссылкой на Secret.
+func BuildBlobStoreConfig(spec v1alpha1.BlobStoreSpec, credentials *S3Credentials) (*BlobStore, error) {
+	config := &BlobStore{
+		Type: spec.Type,
+		Name: spec.Name,
+	}
+
+	if quota := spec.SoftQuota; quota != nil {
+		limit := quota.Limit.Value() / bytesPerMegabyte
+		if limit < 1 {
+			return nil, fmt.Errorf("%w: предел мягкой квоты %s меньше 1 МБ", ErrInvalidBlobStoreSpec, quota.Limit.String())
+		}
+		config.SoftQuota = &BlobStoreSoftQuota{Type: quota.Type, Limit: limit}
+	}
+
+	switch spec.Type {
+	case BlobStoreTypeFile:
+		if spec.File == nil {
+			return nil, fmt.Errorf("%w: для типа File требуются настройки file", ErrInvalidBlobStoreSpec)
+		}
+		config.Path = spec.File.Path
+	case BlobStoreTypeS3:
+		if spec.S3 == nil {
+			return nil, fmt.Errorf("%w: для типа S3 требуются настройки s3", ErrInvalidBlobStoreSpec)
+		}
+		config.BucketConfiguration = buildS3BucketConfiguration(spec.S3, credentials)
+	default:
+		return nil, fmt.Errorf("%w: неподдерживаемый тип %q", ErrInvalidBlobStoreSpec, spec.Type)
+	}
+	return config, nil
+}
+
+// buildS3BucketConfiguration создаёт конфигурацию бакета S3.
+func buildS3BucketConfiguration(s3 *v1alpha1.S3BlobStoreConfig, credentials *S3Credentials) *S3BucketConfiguration {
+	config := &S3BucketConfiguration{
+		Bucket: S3Bucket{
+			Region:     s3.Region,
+			Name:       s3.Bucket,
+			Prefix:     s3.Prefix,
+			Expiration: defaultS3Expiration,
+		},
+	}
+	if config.Bucket.Region == "" {
+		config.Bucket.Region = "DEFAULT"
+	}
+	if s3.Expiration != nil {
+		config.Bucket.Expiration = int(*s3.Expiration)
+	}
+	if credentials != nil {
+		config.BucketSecurity = &S3BucketSecurity{
+			AccessKeyID:     credentials.AccessKeyID,
+			SecretAccessKey: credentials.SecretAccessKey,
+		}
+	}
+	if s3.Endpoint != "" || s3.ForcePathStyle {
+		config.AdvancedBucketConnection = &S3AdvancedBucketConnection{
+			Endpoint:       s3.Endpoint,
+			ForcePathStyle: s3.ForcePathStyle,
+		}
+	}
+	return config
+}
+
+// BlobStoreDiff возвращает различия между желаемой и текущей конфигурацией хранилища.
+// Пустая строка означает, что обновление не требуется. Секретный ключ S3 не учитывается
+// (сервер его не возвращает), его изменение отслеживается по S3Credentials.Hash.
+func BlobStoreDiff(desired, current *BlobStore) string {
+	normalized := *current
+	if bucket := normalized.BucketConfiguration; bucket != nil && desired.BucketConfiguration != nil {
+		config := *bucket
+		// Секции, не заданные в желаемой конфигурации, сервер может вернуть пустыми.
+		if desired.BucketConfiguration.BucketSecurity == nil && emptySecurity(config.BucketSecurity) {
+			config.BucketSecurity = nil
+		}
+		if desired.BucketConfiguration.AdvancedBucketConnection == nil && emptyConnection(config.AdvancedBucketConnection) {
+			config.AdvancedBucketConnection = nil
+		}
+		normalized.BucketConfiguration = &config
+	}
+
+	return cmp.Diff(desired, &normalized,
+		cmpopts.EquateEmpty(),
+		cmpopts.IgnoreFields(S3BucketSecurity{}, "SecretAccessKey"),
+	)
+}
+
+func emptySecurity(security *S3BucketSecurity) bool {
+	return security == nil || security.AccessKeyID == ""
+}
+
+func emptyConnection(connection *S3AdvancedBucketConnection) bool {
+	return connection == nil || (connection.Endpoint == "" && !connection.ForcePathStyle)
+}
@@ -0,0 +1,208 @@
+// Ограничение частоты запросов и автоматический выключатель для клиента Sonatype Nexus
+package nexus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultRateLimitQPS       = 10
+	defaultRateLimitBurst     = 20
+	defaultFailureThreshold   = 5
+	defaultBreakerOpenTimeout = 30 * time.Second
+)
+
+// BreakerState - состояние автоматического выключателя.
+type BreakerState string
+
+const (
+	// BreakerClosed - запросы выполняются в обычном режиме.
+	BreakerClosed BreakerState = "Closed"
+	// BreakerOpen - запросы отклоняются без обращения к Nexus.
+	BreakerOpen BreakerState = "Open"
+	// BreakerHalfOpen - выполняется пробный запрос.
+	BreakerHalfOpen BreakerState = "HalfOpen"
+)
+
+// UnavailableError возвращается, пока выключатель экземпляра разомкнут.
+type UnavailableError struct {
+	// RetryAfter - время до следующей пробной попытки.
+	RetryAfter time.Duration
+}
+
+func (e *UnavailableError) Error() string {
+	return fmt.Sprintf("%s: повтор через %s", ErrNexusUnavailable, e.RetryAfter.Round(time.Second))
+}
+
+// Unwrap позволяет проверять ошибку через errors.Is(err, ErrNexusUnavailable).
+func (e *UnavailableError) Unwrap() error {
+	return ErrNexusUnavailable
+}
+
+// CircuitBreaker размыкается после FailureThreshold подряд неудачных запросов
+// и через OpenTimeout пропускает один пробный запрос. Успешная проба замыкает выключатель.
+type CircuitBreaker struct {
+	mu               sync.Mutex
+	failureThreshold int
+	openTimeout      time.Duration
+	state            BreakerState
+	failures         int
+	openedAt         time.Time
+}
+
+// NewCircuitBreaker создаёт замкнутый выключатель.
+func NewCircuitBreaker(failureThreshold int, openTimeout time.Duration) *CircuitBreaker {
+	b := &CircuitBreaker{state: BreakerClosed}
+	b.configure(failureThreshold, openTimeout)
+	return b
+}
+
+// configure обновляет параметры выключателя, сохраняя его состояние.
+func (b *CircuitBreaker) configure(failureThreshold int, openTimeout time.Duration) {
+	if failureThreshold <= 0 {
+		failureThreshold = defaultFailureThreshold
+	}
+	if openTimeout <= 0 {
+		openTimeout = defaultBreakerOpenTimeout
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failureThreshold = failureThreshold
+	b.openTimeout = openTimeout
+}
+
+// State возвращает текущее состояние выключателя.
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Available возвращает UnavailableError, если запросы к Nexus сейчас отклоняются.
+// В отличие от allow, не занимает пробный запрос.
+func (b *CircuitBreaker) Available() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if remaining := b.remaining(); remaining > 0 {
+			return &UnavailableError{RetryAfter: remaining}
+		}
+	case BreakerHalfOpen:
+		return &UnavailableError{RetryAfter: b.openTimeout}
+	}
+	return nil
+}
+
+// allow проверяет, можно ли выполнить запрос, и переводит выключатель в HalfOpen,
+// когда истекло время ожидания.
+func (b *CircuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if remaining := b.remaining(); remaining > 0 {
+			return &UnavailableError{RetryAfter: remaining}
+		}
+		b.state = BreakerHalfOpen
+		return nil
+	case BreakerHalfOpen:
+		// Пробный запрос уже выполняется.
+		return &UnavailableError{RetryAfter: b.openTimeout}
+	default:
+		return nil
+	}
+}
+
+// success фиксирует успешный запрос.
+func (b *CircuitBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state = BreakerClosed
+	b.failures = 0
+}
+
+// failure фиксирует неудачный запрос.
+func (b *CircuitBreaker) failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.failureThreshold {
+		b.state = BreakerOpen
+		b.openedAt = time.Now()
+	}
+}
+
+func (b *CircuitBreaker) remaining() time.Duration {
+	return b.openTimeout - time.Since(b.openedAt)
+}
+
+// guardedTransport ограничивает частоту запросов и учитывает их результат в выключателе.
+// Применяется к каждой попытке, включая повторы resty. Без breaker только ограничивает частоту.
+type guardedTransport struct {
+	next    http.RoundTripper
+	limiter *rate.Limiter
+	breaker *CircuitBreaker
+}
+
+func (t *guardedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.breaker == nil {
+		if err := t.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+		return t.next.RoundTrip(req)
+	}
+
+	if err := t.breaker.allow(); err != nil {
+		return nil, err
+	}
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		// Отмена контекста не говорит о состоянии Nexus, освобождаем пробу.
+		t.release()
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	switch {
+	case err != nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) &&
+		req.Context().Err() != nil:
+		t.release()
+	case err != nil || IsRetryableStatus(resp.StatusCode):
+		t.breaker.failure()
+	default:
+		t.breaker.success()
+	}
+	return resp, err
+}
+
+// release возвращает выключатель из HalfOpen в Open, если проба не была выполнена.
+func (t *guardedTransport) release() {
+	t.breaker.mu.Lock()
+	defer t.breaker.mu.Unlock()
+	if t.breaker.state == BreakerHalfOpen {
+		t.breaker.state = BreakerOpen
+	}
+}
+
+// rateLimit возвращает параметры ограничителя с учётом значений по умолчанию.
+func (cfg Config) rateLimit() (rate.Limit, int) {
+	qps, burst := cfg.QPS, cfg.Burst
+	if qps <= 0 {
+		qps = defaultRateLimitQPS
+	}
+	if burst <= 0 {
+		burst = defaultRateLimitBurst
+	}
+	return rate.Limit(qps), burst
+}
@@ -0,0 +1,74 @@
+// Кэш клиентов для нескольких экземпляров Sonatype Nexus
+package nexus
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+)
+
+// ClientCache хранит клиенты Nexus по ключу экземпляра.
+// Клиент пересоздаётся, если конфигурация экземпляра изменилась.
+type ClientCache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	client      *Client
+	fingerprint string
+}
+
+// NewClientCache создаёт пустой кэш клиентов.
+func NewClientCache() *ClientCache {
+	return &ClientCache{entries: make(map[string]cacheEntry)}
+}
+
+// Get возвращает клиент для экземпляра key, создавая его при отсутствии
+// или при изменении конфигурации.
+func (c *ClientCache) Get(key string, cfg Config) (*Client, error) {
+	fingerprint := cfg.fingerprint()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if ok && entry.fingerprint == fingerprint {
+		return entry.client, nil
+	}
+	if ok {
+		// Ограничитель и выключатель сохраняются при смене учётных данных или настроек.
+		cfg.limiter = entry.client.limiter
+		cfg.breaker = entry.client.Breaker
+	}
+
+	client, err := NewClientFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	c.entries[key] = cacheEntry{client: client, fingerprint: fingerprint}
+	return client, nil
+}
+
+// Invalidate удаляет клиент экземпляра key из кэша.
+func (c *ClientCache) Invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// fingerprint вычисляет отпечаток конфигурации, чтобы не хранить пароль в открытом виде.
+func (cfg Config) fingerprint() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%s\x00%t", cfg.BaseURL, cfg.Username, cfg.Password, cfg.Timeout, cfg.Debug)
+	fmt.Fprintf(h, "\x00%g\x00%d\x00%d\x00%s", cfg.QPS, cfg.Burst, cfg.FailureThreshold, cfg.OpenTimeout)
+	if t := cfg.TLS; t != nil {
+		fmt.Fprintf(h, "\x00%s\x00%d\x00%t\x00", t.ServerName, t.MinVersion, t.InsecureSkipVerify)
+		for _, data := range [][]byte{t.CAPEM, t.CertPEM, t.KeyPEM} {
+			h.Write(data)
+			h.Write([]byte{0})
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,128 @@
+// Работа с политиками очистки в Sonatype Nexus
+package nexus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/mkostelcev/nexus-operator/api/v1alpha1"
+)
+
+// CleanupPolicyExists проверяет, существует ли политика очистки.
+func (c *Client) CleanupPolicyExists(ctx context.Context, name string) (bool, error) {
+	_, err := c.GetCleanupPolicy(ctx, name)
+	if errors.Is(err, ErrCleanupPolicyNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// GetCleanupPolicy получает конфигурацию политики очистки.
+func (c *Client) GetCleanupPolicy(ctx context.Context, name string) (*CleanupPolicy, error) {
+	resp, err := c.Resty.R().
+		SetContext(ctx).
+		SetPathParam("name", name).
+		SetResult(&CleanupPolicy{}).
+		Get("/service/rest/v1/cleanup-policies/{name}")
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		return resp.Result().(*CleanupPolicy), nil
+	case 404:
+		return nil, ErrCleanupPolicyNotFound
+	default:
+		return nil, NewAPIError(resp)
+	}
+}
+
+// CreateCleanupPolicy создаёт политику очистки.
+func (c *Client) CreateCleanupPolicy(ctx context.Context, policy *CleanupPolicy) error {
+	c.Logger.Infof("Создание политики очистки %s", policy.Name)
+	resp, err := c.Resty.R().
+		SetContext(ctx).
+		SetBody(policy).
+		SetHeader("Content-Type", "application/json").
+		Post("/service/rest/v1/cleanup-policies")
+	if err != nil {
+		return fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+
+	// В зависимости от версии Nexus отвечает 200, 201 или 204
+	if resp.StatusCode() >= 200 && resp.StatusCode() < 300 {
+		return nil
+	}
+	return NewAPIError(resp)
+}
+
+// UpdateCleanupPolicy обновляет существующую политику очистки.
+func (c *Client) UpdateCleanupPolicy(ctx context.Context, name string, policy *CleanupPolicy) error {
+	c.Logger.Infof("Обновление политики очистки %s", name)
+	resp, err := c.Resty.R().
+		SetContext(ctx).
+		SetPathParam("name", name).
+		SetBody(policy).
+		SetHeader("Content-Type", "application/json").
+		Put("/service/rest/v1/cleanup-policies/{name}")
+	if err != nil {
+		return fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+
+	if resp.StatusCode() >= 200 && resp.StatusCode() < 300 {
+		return nil
+	}
+	return NewAPIError(resp)
+}
+
+// DeleteCleanupPolicy удаляет политику очистки.
+func (c *Client) DeleteCleanupPolicy(ctx context.Context, name string) error {
+	resp, err := c.Resty.R().
+		SetContext(ctx).
+		SetPathParam("name", name).
+		Delete("/service/rest/v1/cleanup-policies/{name}")
+	if err != nil {
+		return fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+
+	switch resp.StatusCode() {
+	case 200, 204:
+		return nil
+	case 404:
+		return ErrCleanupPolicyNotFound
+	default:
+		return NewAPIError(resp)
+	}
+}
+
+// BuildCleanupPolicyConfig создаёт конфигурацию политики очистки.
+func BuildCleanupPolicyConfig(spec v1alpha1.CleanupPolicySpec) *CleanupPolicy {
+	return &CleanupPolicy{
+		Name:                    spec.Name,
+		Format:                  spec.Format,
+		Notes:                   spec.Notes,
+		CriteriaLastBlobUpdated: days(spec.Criteria.LastBlobUpdated),
+		CriteriaLastDownloaded:  days(spec.Criteria.LastDownloaded),
+		CriteriaReleaseType:     spec.Criteria.ReleaseType,
+		CriteriaAssetRegex:      spec.Criteria.AssetRegex,
+	}
+}
+
+func days(value *int32) *int {
+	if value == nil {
+		return nil
+	}
+	v := int(*value)
+	return &v
+}
+
+// CleanupPolicyDiff возвращает различия между желаемой и текущей политикой очистки.
+// Пустая строка означает, что обновление не требуется.
+func CleanupPolicyDiff(desired, current *CleanupPolicy) string {
+	return cmp.Diff(desired, current, cmpopts.EquateEmpty())
+}
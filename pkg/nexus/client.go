@@ -1,13 +1,19 @@
 package nexus
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"regexp"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -24,6 +30,24 @@ const (
 	TypeRawHosted    = "raw-hosted"
 	TypeRawProxy     = "raw-proxy"
 	TypeRawGroup     = "raw-group"
+	TypePypiHosted   = "pypi-hosted"
+	TypePypiProxy    = "pypi-proxy"
+	TypePypiGroup    = "pypi-group"
+	TypeHelmHosted   = "helm-hosted"
+	TypeHelmProxy    = "helm-proxy"
+	TypeAptHosted    = "apt-hosted"
+	TypeAptProxy     = "apt-proxy"
+	TypeYumHosted    = "yum-hosted"
+	TypeYumProxy     = "yum-proxy"
+	TypeYumGroup     = "yum-group"
+	TypeNugetHosted  = "nuget-hosted"
+	TypeNugetProxy   = "nuget-proxy"
+	TypeNugetGroup   = "nuget-group"
+	TypeGoProxy      = "go-proxy"
+	TypeGoGroup      = "go-group"
+	TypeCargoHosted  = "cargo-hosted"
+	TypeCargoProxy   = "cargo-proxy"
+	TypeCargoGroup   = "cargo-group"
 	// Privilege types
 	PrivilegeTypeWildcard                  = "wildcard"
 	PrivilegeTypeApplication               = "application"
@@ -33,14 +57,27 @@ const (
 	PrivilegeTypeScript                    = "script"
 
 	RoleAPIPath = "/service/rest/v1/security/roles"
+
+	defaultTimeout = 30 * time.Second
+
+	// Параметры повторов запросов при временных ошибках.
+	defaultRetryCount       = 3
+	defaultRetryWaitTime    = 500 * time.Millisecond
+	defaultRetryMaxWaitTime = 10 * time.Second
 )
 
 // Ошибки для клиента Nexus.
 var (
 	ErrMissingEnvVars               = errors.New("не заданы необходимые переменные окружения для клиента Nexus")
+	ErrInvalidConfig                = errors.New("некорректная конфигурация клиента Nexus")
+	ErrNexusUnavailable             = errors.New("Nexus временно недоступен")
+	ErrNexusReadOnly                = errors.New("Nexus доступен только для чтения")
+	ErrUnsupported                  = errors.New("настройки не поддерживаются экземпляром Nexus")
 	ErrUnexpectedResponse           = errors.New("неожиданный статус ответа")
 	ErrRepositoryNotFound           = errors.New("репозиторий не найден")
 	ErrUnsupportedRepoType          = errors.New("неподдерживаемый тип репозитория")
+	ErrInvalidRemoteURL             = errors.New("некорректный адрес удалённого репозитория")
+	ErrInvalidRepositorySpec        = errors.New("некорректная спецификация репозитория")
 	ErrContentSelectorNotFound      = errors.New("content-selector не найден")
 	ErrContentSelectorAlreadyExists = errors.New("content-selector уже существует")
 	ErrPrivilegeNotFound            = errors.New("привелегия не найдена")
@@ -54,70 +91,230 @@ var (
 	ErrUnsupportedPrivilegeType     = errors.New("неподдерживаемый тип привелегии")
 	ErrRoleNotFound                 = errors.New("роль не найдена")
 	ErrRoleAlreadyExists            = errors.New("роль уже существует")
+	ErrCleanupPolicyNotFound        = errors.New("политика очистки не найдена")
+	ErrBlobStoreNotFound            = errors.New("хранилище blob-объектов не найдено")
+	ErrInvalidBlobStoreSpec         = errors.New("некорректная спецификация хранилища blob-объектов")
+	ErrRoutingRuleNotFound          = errors.New("правило маршрутизации не найдено")
+	ErrRoutingRuleInUse             = errors.New("правило маршрутизации назначено репозиториям")
 
-	clientInstance *Client // Глобальный клиент Nexus
-	initError      error   // Ошибка инициализации клиента
+	clientMu       sync.Mutex // Защищает глобальный клиент
+	clientInstance *Client    // Глобальный клиент Nexus
 )
 
 // Client представляет клиент для взаимодействия с Nexus API.
 type Client struct {
 	Resty  *resty.Client
 	Logger *logrus.Logger
+	// Breaker - автоматический выключатель экземпляра, общий для всех контроллеров.
+	Breaker *CircuitBreaker
+
+	// probe выполняет проверки состояния: без повторов и без учёта в выключателе.
+	probe *resty.Client
+
+	limiter    *rate.Limiter
+	serverInfo serverInfoCache
 }
 
-// initClient инициализирует глобальный клиент Nexus.
-func initClient() {
+// initClient создаёт глобальный клиент Nexus из ENV-переменных.
+func initClient() (*Client, error) {
 	baseURL := os.Getenv("NEXUS_URL")
 	username := os.Getenv("NEXUS_USER")
 	password := os.Getenv("NEXUS_PASSWORD")
 
 	if baseURL == "" || username == "" || password == "" {
-		initError = fmt.Errorf("%w: baseURL, username или password пусты", ErrMissingEnvVars)
-		return
+		return nil, fmt.Errorf("%w: baseURL, username или password пусты", ErrMissingEnvVars)
 	}
 
-	client, err := NewClient(baseURL, username, password)
-	if err != nil {
-		initError = err
-		return
-	}
-	clientInstance = client
+	return NewClient(baseURL, username, password)
 }
 
 // GetClient возвращает глобальный клиент Nexus.
+// Ошибка инициализации не сохраняется: следующий вызов повторит попытку.
 func GetClient() (*Client, error) {
-	if initError != nil {
-		return nil, initError
-	}
+	clientMu.Lock()
+	defer clientMu.Unlock()
 
-	if clientInstance == nil {
-		initClient()
-		if initError != nil {
-			return nil, initError
-		}
+	if clientInstance != nil {
+		return clientInstance, nil
 	}
 
+	client, err := initClient()
+	if err != nil {
+		return nil, err
+	}
+	clientInstance = client
 	return clientInstance, nil
 }
 
+// Config описывает параметры подключения клиента к экземпляру Nexus.
+type Config struct {
+	BaseURL  string
+	Username string
+	Password string
+	Timeout  time.Duration
+	Debug    bool
+	TLS      *TLSConfig
+
+	// QPS и Burst - параметры ограничителя частоты запросов (token bucket).
+	QPS   float64
+	Burst int
+	// FailureThreshold - число неудачных запросов подряд, после которого выключатель размыкается.
+	FailureThreshold int
+	// OpenTimeout - время, в течение которого запросы отклоняются до пробной попытки.
+	OpenTimeout time.Duration
+
+	// limiter и breaker переносятся из предыдущего клиента экземпляра при его пересоздании.
+	limiter *rate.Limiter
+	breaker *CircuitBreaker
+}
+
 // NewClient создаёт новый экземпляр клиента Nexus.
+// Отладочный журнал запросов включается ENV-переменной NEXUS_DEBUG=true.
 func NewClient(baseURL, username, password string) (*Client, error) {
-	client := resty.New().
-		SetBaseURL(baseURL).
-		SetBasicAuth(username, password).
-		SetTimeout(30 * time.Second).
-		SetDebug(true) // Отладка HERE!
+	return NewClientFromConfig(Config{
+		BaseURL:  baseURL,
+		Username: username,
+		Password: password,
+		Timeout:  defaultTimeout,
+		Debug:    os.Getenv("NEXUS_DEBUG") == "true",
+	})
+}
+
+// NewClientFromConfig создаёт новый экземпляр клиента Nexus по конфигурации.
+func NewClientFromConfig(cfg Config) (*Client, error) {
+	if cfg.BaseURL == "" || cfg.Username == "" || cfg.Password == "" {
+		return nil, fmt.Errorf("%w: baseURL, username или password пусты", ErrInvalidConfig)
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	var tlsConfig *tls.Config
+	if cfg.TLS != nil {
+		var err error
+		if tlsConfig, err = cfg.TLS.build(); err != nil {
+			return nil, err
+		}
+	}
+
+	client := newRestyClient(cfg, tlsConfig).
+		SetRetryCount(defaultRetryCount).
+		SetRetryWaitTime(defaultRetryWaitTime).
+		SetRetryMaxWaitTime(defaultRetryMaxWaitTime).
+		SetRetryAfter(retryAfter).
+		AddRetryCondition(shouldRetry)
+
+	limit, burst := cfg.rateLimit()
+	limiter := cfg.limiter
+	if limiter == nil {
+		limiter = rate.NewLimiter(limit, burst)
+	} else {
+		limiter.SetLimit(limit)
+		limiter.SetBurst(burst)
+	}
+
+	breaker := cfg.breaker
+	if breaker == nil {
+		breaker = NewCircuitBreaker(cfg.FailureThreshold, cfg.OpenTimeout)
+	} else {
+		breaker.configure(cfg.FailureThreshold, cfg.OpenTimeout)
+	}
+
+	// Транспорт оборачивается после настройки TLS: resty изменяет только *http.Transport.
+	client.SetTransport(&guardedTransport{
+		next:    client.GetClient().Transport,
+		limiter: limiter,
+		breaker: breaker,
+	})
+
+	// Проверки состояния не повторяются и не влияют на выключатель: ответ 503 экземпляра
+	// в режиме только для чтения не должен размыкать его для контроллеров.
+	probe := newRestyClient(cfg, tlsConfig)
+	probe.SetTransport(&guardedTransport{
+		next:    probe.GetClient().Transport,
+		limiter: limiter,
+	})
 
 	logger := logrus.New()
 	logger.SetFormatter(&logrus.JSONFormatter{})
 
 	return &Client{
-		Resty:  client,
-		Logger: logger,
+		Resty:   client,
+		Logger:  logger,
+		Breaker: breaker,
+		probe:   probe,
+		limiter: limiter,
 	}, nil
 }
 
-// NewUnexpectedResponseError создаёт ошибку с информацией о статусе и тексте ответа.
-func NewUnexpectedResponseError(statusCode int, responseText string) error {
-	return fmt.Errorf("%w: статус %d, текст: %s", ErrUnexpectedResponse, statusCode, responseText)
+// newRestyClient создаёт HTTP-клиент с адресом, учётными данными и TLS экземпляра.
+func newRestyClient(cfg Config, tlsConfig *tls.Config) *resty.Client {
+	client := resty.New().
+		SetBaseURL(cfg.BaseURL).
+		SetBasicAuth(cfg.Username, cfg.Password).
+		SetTimeout(cfg.Timeout).
+		SetDebug(cfg.Debug).
+		OnRequestLog(redactRequestLog)
+	if tlsConfig != nil {
+		client.SetTLSClientConfig(tlsConfig)
+	}
+	return client
+}
+
+// Available возвращает ошибку ErrNexusUnavailable, пока выключатель экземпляра разомкнут.
+// Контроллеры проверяют её перед обращением к API, чтобы не нагружать недоступный Nexus.
+func (c *Client) Available() error {
+	return c.Breaker.Available()
+}
+
+// sensitiveFieldPattern находит в JSON поля с паролями, токенами и ключами подписи.
+var sensitiveFieldPattern = regexp.MustCompile(`("(?:password|passphrase|keypair|secretAccessKey|bearerToken)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// sensitiveHeaders - заголовки с учётными данными, скрываемые в отладочном журнале запросов.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization"}
+
+// redactRequestLog скрывает пароли, токены и ключи подписи в отладочном журнале запросов.
+func redactRequestLog(log *resty.RequestLog) error {
+	log.Body = sensitiveFieldPattern.ReplaceAllString(log.Body, `$1"***"`)
+	for _, header := range sensitiveHeaders {
+		if log.Header.Get(header) != "" {
+			log.Header.Set(header, "***")
+		}
+	}
+	return nil
+}
+
+// idempotentMethods - методы, повтор которых не меняет результат запроса.
+var idempotentMethods = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodHead:   true,
+	http.MethodPut:    true,
+	http.MethodDelete: true,
+}
+
+// shouldRetry повторяет идемпотентные запросы (GET, PUT, DELETE) при статусах 429/5xx и сетевых сбоях.
+// POST не повторяется: Nexus мог создать объект до ошибки, и повтор завершился бы ошибкой
+// "уже существует". Такой запрос повторит следующая обработка ресурса, проверив существование объекта.
+// Пауза между попытками растёт экспоненциально со случайным разбросом.
+func shouldRetry(resp *resty.Response, err error) bool {
+	if resp == nil || resp.Request == nil || !idempotentMethods[resp.Request.Method] {
+		return false
+	}
+	if err != nil {
+		return isTransientError(err)
+	}
+	return resp != nil && IsRetryableStatus(resp.StatusCode())
+}
+
+// retryAfter учитывает заголовок Retry-After (в секундах) в ответах 429 и 503.
+// Нулевое значение означает паузу по умолчанию.
+func retryAfter(_ *resty.Client, resp *resty.Response) (time.Duration, error) {
+	if resp == nil {
+		return 0, nil
+	}
+	seconds, err := strconv.Atoi(resp.Header().Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0, nil
+	}
+	return time.Duration(seconds) * time.Second, nil
 }
@@ -0,0 +1,273 @@
+package nexus_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mkostelcev/nexus-operator/api/v1alpha1"
+	"github.com/mkostelcev/nexus-operator/pkg/nexus"
+	"github.com/mkostelcev/nexus-operator/pkg/nexus/fake"
+)
+
+// newTestClient запускает fake-сервер и создаёт клиент с короткими паузами между повторами.
+func newTestClient(t *testing.T, configure func(*nexus.Config)) (*fake.Server, *nexus.Client) {
+	t.Helper()
+
+	srv := fake.NewServer()
+	t.Cleanup(srv.Close)
+
+	cfg := srv.Config()
+	if configure != nil {
+		configure(&cfg)
+	}
+	c, err := nexus.NewClientFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewClientFromConfig: %v", err)
+	}
+	c.Resty.SetRetryWaitTime(time.Millisecond).SetRetryMaxWaitTime(5 * time.Millisecond)
+	return srv, c
+}
+
+func mavenHostedSpec(name string) v1alpha1.RepositorySpec {
+	return v1alpha1.RepositorySpec{
+		Name:    name,
+		Type:    nexus.TypeMavenHosted,
+		Online:  true,
+		Storage: v1alpha1.StorageConfig{BlobStoreName: "default", WritePolicy: "ALLOW_ONCE"},
+		Maven:   &v1alpha1.MavenConfig{VersionPolicy: "RELEASE", LayoutPolicy: "STRICT"},
+	}
+}
+
+func TestRepositoryCRUD(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestClient(t, nil)
+
+	spec := mavenHostedSpec("maven-releases")
+	desired, err := nexus.BuildRepositoryConfig(v1alpha1.Repository{Spec: spec}, nexus.RepositorySecrets{})
+	if err != nil {
+		t.Fatalf("BuildRepositoryConfig: %v", err)
+	}
+
+	if exists, err := c.RepositoryExists(ctx, spec.Name); err != nil || exists {
+		t.Fatalf("RepositoryExists до создания = %t, %v", exists, err)
+	}
+	if err := c.CreateRepository(ctx, spec.Type, desired); err != nil {
+		t.Fatalf("CreateRepository: %v", err)
+	}
+	if exists, err := c.RepositoryExists(ctx, spec.Name); err != nil || !exists {
+		t.Fatalf("RepositoryExists после создания = %t, %v", exists, err)
+	}
+
+	current, err := c.GetRepository(ctx, spec.Type, spec.Name)
+	if err != nil {
+		t.Fatalf("GetRepository: %v", err)
+	}
+	if diff := nexus.RepositoryDiff(desired, current); diff != "" {
+		t.Errorf("созданный репозиторий отличается от желаемого:\n%s", diff)
+	}
+
+	spec.Storage.WritePolicy = "ALLOW"
+	updated, err := nexus.BuildRepositoryConfig(v1alpha1.Repository{Spec: spec}, nexus.RepositorySecrets{})
+	if err != nil {
+		t.Fatalf("BuildRepositoryConfig: %v", err)
+	}
+	if diff := nexus.RepositoryDiff(updated, current); diff == "" {
+		t.Error("RepositoryDiff не обнаружил изменение writePolicy")
+	}
+	if err := c.UpdateRepository(ctx, spec.Type, updated); err != nil {
+		t.Fatalf("UpdateRepository: %v", err)
+	}
+	current, err = c.GetRepository(ctx, spec.Type, spec.Name)
+	if err != nil {
+		t.Fatalf("GetRepository: %v", err)
+	}
+	if current.Storage.WritePolicy != "ALLOW" {
+		t.Errorf("writePolicy = %q, ожидалось ALLOW", current.Storage.WritePolicy)
+	}
+
+	if err := c.DeleteRepository(ctx, spec.Name); err != nil {
+		t.Fatalf("DeleteRepository: %v", err)
+	}
+	if _, err := c.GetRepository(ctx, spec.Type, spec.Name); !errors.Is(err, nexus.ErrRepositoryNotFound) {
+		t.Errorf("GetRepository после удаления: %v, ожидалась ErrRepositoryNotFound", err)
+	}
+}
+
+func TestCleanupPolicyCRUD(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestClient(t, nil)
+
+	days := int32(30)
+	spec := v1alpha1.CleanupPolicySpec{
+		Name:     "stale-snapshots",
+		Format:   "maven2",
+		Criteria: v1alpha1.CleanupPolicyCriteria{LastDownloaded: &days},
+	}
+	desired := nexus.BuildCleanupPolicyConfig(spec)
+
+	if err := c.CreateCleanupPolicy(ctx, desired); err != nil {
+		t.Fatalf("CreateCleanupPolicy: %v", err)
+	}
+	current, err := c.GetCleanupPolicy(ctx, spec.Name)
+	if err != nil {
+		t.Fatalf("GetCleanupPolicy: %v", err)
+	}
+	if diff := nexus.CleanupPolicyDiff(desired, current); diff != "" {
+		t.Errorf("созданная политика отличается от желаемой:\n%s", diff)
+	}
+
+	spec.Notes = "удаление давно не скачанных компонентов"
+	if err := c.UpdateCleanupPolicy(ctx, spec.Name, nexus.BuildCleanupPolicyConfig(spec)); err != nil {
+		t.Fatalf("UpdateCleanupPolicy: %v", err)
+	}
+	if current, err = c.GetCleanupPolicy(ctx, spec.Name); err != nil || current.Notes != spec.Notes {
+		t.Fatalf("GetCleanupPolicy после обновления: %+v, %v", current, err)
+	}
+
+	if err := c.DeleteCleanupPolicy(ctx, spec.Name); err != nil {
+		t.Fatalf("DeleteCleanupPolicy: %v", err)
+	}
+	if err := c.DeleteCleanupPolicy(ctx, spec.Name); !errors.Is(err, nexus.ErrCleanupPolicyNotFound) {
+		t.Errorf("повторное удаление: %v, ожидалась ErrCleanupPolicyNotFound", err)
+	}
+}
+
+func TestDeleteRoutingRuleInUse(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestClient(t, nil)
+
+	rule := nexus.BuildRoutingRuleConfig(v1alpha1.RoutingRuleSpec{
+		Name:     "block-internal",
+		Mode:     "BLOCK",
+		Matchers: []string{"^/com/example/.*"},
+	})
+	if err := c.CreateRoutingRule(ctx, rule); err != nil {
+		t.Fatalf("CreateRoutingRule: %v", err)
+	}
+
+	spec := v1alpha1.RepositorySpec{
+		Name:        "raw-proxy",
+		Type:        nexus.TypeRawProxy,
+		Storage:     v1alpha1.StorageConfig{BlobStoreName: "default"},
+		Proxy:       &v1alpha1.ProxyConfig{RemoteUrl: "https://example.com/raw"},
+		RoutingRule: &v1alpha1.RoutingRuleAssignment{Name: rule.Name},
+	}
+	repo, err := nexus.BuildRepositoryConfig(v1alpha1.Repository{Spec: spec}, nexus.RepositorySecrets{})
+	if err != nil {
+		t.Fatalf("BuildRepositoryConfig: %v", err)
+	}
+	if err := c.CreateRepository(ctx, spec.Type, repo); err != nil {
+		t.Fatalf("CreateRepository: %v", err)
+	}
+
+	if err := c.DeleteRoutingRule(ctx, rule.Name); !errors.Is(err, nexus.ErrRoutingRuleInUse) {
+		t.Fatalf("DeleteRoutingRule для назначенного правила: %v, ожидалась ErrRoutingRuleInUse", err)
+	}
+	if err := c.DeleteRepository(ctx, spec.Name); err != nil {
+		t.Fatalf("DeleteRepository: %v", err)
+	}
+	if err := c.DeleteRoutingRule(ctx, rule.Name); err != nil {
+		t.Fatalf("DeleteRoutingRule: %v", err)
+	}
+}
+
+func TestRetryOnTransientErrors(t *testing.T) {
+	srv, c := newTestClient(t, nil)
+	srv.InjectFault(fake.Fault{Method: http.MethodGet, PathPrefix: "/service/rest/v1/repositories", Status: 503, Times: 2})
+
+	if _, err := c.RepositoryExists(context.Background(), "maven-releases"); err != nil {
+		t.Fatalf("RepositoryExists: %v", err)
+	}
+	if got := srv.RequestCount(http.MethodGet, "/service/rest/v1/repositories"); got != 3 {
+		t.Errorf("запросов = %d, ожидалось 3 (две ошибки 503 и успешный повтор)", got)
+	}
+	if state := c.Breaker.State(); state != nexus.BreakerClosed {
+		t.Errorf("состояние выключателя = %s, ожидалось %s", state, nexus.BreakerClosed)
+	}
+}
+
+func TestNoRetryOnClientErrors(t *testing.T) {
+	srv, c := newTestClient(t, nil)
+	srv.InjectFault(fake.Fault{Method: http.MethodGet, PathPrefix: "/service/rest/v1/repositories", Status: 400, Times: 1})
+
+	_, err := c.RepositoryExists(context.Background(), "maven-releases")
+	if nexus.StatusCode(err) != 400 || nexus.IsRetryable(err) {
+		t.Fatalf("RepositoryExists: %v, ожидалась окончательная ошибка 400", err)
+	}
+	if got := srv.RequestCount(http.MethodGet, "/service/rest/v1/repositories"); got != 1 {
+		t.Errorf("запросов = %d, ожидался 1", got)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	const openTimeout = 50 * time.Millisecond
+	srv, c := newTestClient(t, func(cfg *nexus.Config) {
+		cfg.FailureThreshold = 2
+		cfg.OpenTimeout = openTimeout
+	})
+	srv.InjectFault(fake.Fault{Status: 503})
+
+	ctx := context.Background()
+	if _, err := c.RepositoryExists(ctx, "maven-releases"); !errors.Is(err, nexus.ErrNexusUnavailable) {
+		t.Fatalf("RepositoryExists при недоступном Nexus: %v, ожидалась ErrNexusUnavailable", err)
+	}
+	if state := c.Breaker.State(); state != nexus.BreakerOpen {
+		t.Fatalf("состояние выключателя = %s, ожидалось %s", state, nexus.BreakerOpen)
+	}
+	// Разомкнутый выключатель отклоняет запросы без обращения к серверу.
+	if got := srv.RequestCount("", ""); got != 2 {
+		t.Errorf("запросов = %d, ожидалось 2 (порог срабатывания)", got)
+	}
+	if err := c.Available(); !errors.Is(err, nexus.ErrNexusUnavailable) {
+		t.Errorf("Available: %v, ожидалась ErrNexusUnavailable", err)
+	}
+
+	srv.ClearFaults()
+	time.Sleep(openTimeout)
+	if _, err := c.RepositoryExists(ctx, "maven-releases"); err != nil {
+		t.Fatalf("пробный запрос: %v", err)
+	}
+	if state := c.Breaker.State(); state != nexus.BreakerClosed {
+		t.Errorf("состояние выключателя после успешной пробы = %s, ожидалось %s", state, nexus.BreakerClosed)
+	}
+}
+
+func TestCheckStatusBypassesBreaker(t *testing.T) {
+	srv, c := newTestClient(t, func(cfg *nexus.Config) {
+		cfg.FailureThreshold = 1
+	})
+	srv.SetReadOnly(true)
+
+	for i := 0; i < 3; i++ {
+		if err := c.CheckStatus(context.Background()); !errors.Is(err, nexus.ErrNexusReadOnly) {
+			t.Fatalf("CheckStatus: %v, ожидалась ErrNexusReadOnly", err)
+		}
+	}
+	// Ответ 503 на /status/writable не повторяется и не размыкает выключатель.
+	if got := srv.RequestCount(http.MethodGet, "/service/rest/v1/status/writable"); got != 3 {
+		t.Errorf("запросов /status/writable = %d, ожидалось 3", got)
+	}
+	if state := c.Breaker.State(); state != nexus.BreakerClosed {
+		t.Errorf("состояние выключателя = %s, ожидалось %s", state, nexus.BreakerClosed)
+	}
+}
+
+func TestNoRetryForCreate(t *testing.T) {
+	srv, c := newTestClient(t, nil)
+	srv.InjectFault(fake.Fault{Method: http.MethodPost, Status: 503, Times: 1})
+
+	spec := mavenHostedSpec("maven-releases")
+	repo, err := nexus.BuildRepositoryConfig(v1alpha1.Repository{Spec: spec}, nexus.RepositorySecrets{})
+	if err != nil {
+		t.Fatalf("BuildRepositoryConfig: %v", err)
+	}
+	if err := c.CreateRepository(context.Background(), spec.Type, repo); nexus.StatusCode(err) != 503 {
+		t.Fatalf("CreateRepository: %v, ожидалась ошибка 503 без повтора", err)
+	}
+	if got := srv.RequestCount(http.MethodPost, ""); got != 1 {
+		t.Errorf("запросов POST = %d, ожидался 1", got)
+	}
+}
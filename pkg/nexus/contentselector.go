@@ -36,7 +36,7 @@ func (c *Client) GetContentSelector(ctx context.Context, name string) (*ContentS
 	case 404:
 		return nil, ErrContentSelectorNotFound
 	default:
-		return nil, NewUnexpectedResponseError(resp.StatusCode(), resp.String())
+		return nil, NewAPIError(resp)
 	}
 }
 
@@ -79,7 +79,7 @@ func (c *Client) CreateContentSelector(ctx context.Context, name, description, e
 		return nil
 	}
 
-	return NewUnexpectedResponseError(resp.StatusCode(), resp.String())
+	return NewAPIError(resp)
 }
 
 // UpdateContentSelector обновляет существующий Content Selector
@@ -112,7 +112,7 @@ func (c *Client) UpdateContentSelector(ctx context.Context, name, description, e
 		return nil
 	}
 
-	return NewUnexpectedResponseError(resp.StatusCode(), resp.String())
+	return NewAPIError(resp)
 }
 
 // DeleteContentSelector удаляет Content Selector
@@ -141,7 +141,7 @@ func (c *Client) DeleteContentSelector(ctx context.Context, name string) error {
 		return nil
 	}
 
-	return NewUnexpectedResponseError(resp.StatusCode(), resp.String())
+	return NewAPIError(resp)
 }
 
 // ContentSelectorExists проверяет существование Content Selector
@@ -167,6 +167,6 @@ func (c *Client) ContentSelectorExists(ctx context.Context, name string) (bool,
 	case 404:
 		return false, nil
 	default:
-		return false, NewUnexpectedResponseError(resp.StatusCode(), resp.String())
+		return false, NewAPIError(resp)
 	}
 }
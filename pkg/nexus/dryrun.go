@@ -0,0 +1,126 @@
+// Режим dry-run для API Sonatype Nexus
+package nexus
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+)
+
+// DryRun возвращает декоратор, который выполняет чтение из Nexus, но вместо
+// изменяющих запросов только записывает их в журнал.
+func DryRun(log logr.Logger) Decorator {
+	return func(api API) API {
+		return &dryRunAPI{API: api, log: log.WithValues("dryRun", true)}
+	}
+}
+
+type dryRunAPI struct {
+	API
+	log logr.Logger
+}
+
+func (d *dryRunAPI) CreateRepository(_ context.Context, repoType string, repo *Repository) error {
+	d.log.Info("Пропущено создание репозитория", "type", repoType, "name", repo.Name)
+	return nil
+}
+
+func (d *dryRunAPI) UpdateRepository(_ context.Context, repoType string, repo *Repository) error {
+	d.log.Info("Пропущено обновление репозитория", "type", repoType, "name", repo.Name)
+	return nil
+}
+
+func (d *dryRunAPI) DeleteRepository(_ context.Context, name string) error {
+	d.log.Info("Пропущено удаление репозитория", "name", name)
+	return nil
+}
+
+func (d *dryRunAPI) CreateRole(_ context.Context, role Role) error {
+	d.log.Info("Пропущено создание роли", "roleID", role.ID)
+	return nil
+}
+
+func (d *dryRunAPI) UpdateRole(_ context.Context, roleID string, _ Role) error {
+	d.log.Info("Пропущено обновление роли", "roleID", roleID)
+	return nil
+}
+
+func (d *dryRunAPI) DeleteRole(_ context.Context, roleID string) error {
+	d.log.Info("Пропущено удаление роли", "roleID", roleID)
+	return nil
+}
+
+func (d *dryRunAPI) CreatePrivilege(_ context.Context, config *Privilege) error {
+	d.log.Info("Пропущено создание привилегии", "name", config.Name)
+	return nil
+}
+
+func (d *dryRunAPI) UpdatePrivilege(_ context.Context, name string, _ *Privilege) error {
+	d.log.Info("Пропущено обновление привилегии", "name", name)
+	return nil
+}
+
+func (d *dryRunAPI) DeletePrivilege(_ context.Context, name string) error {
+	d.log.Info("Пропущено удаление привилегии", "name", name)
+	return nil
+}
+
+func (d *dryRunAPI) CreateContentSelector(_ context.Context, name, _, _ string) error {
+	d.log.Info("Пропущено создание content-selector", "name", name)
+	return nil
+}
+
+func (d *dryRunAPI) UpdateContentSelector(_ context.Context, name, _, _ string) error {
+	d.log.Info("Пропущено обновление content-selector", "name", name)
+	return nil
+}
+
+func (d *dryRunAPI) DeleteContentSelector(_ context.Context, name string) error {
+	d.log.Info("Пропущено удаление content-selector", "name", name)
+	return nil
+}
+
+func (d *dryRunAPI) CreateCleanupPolicy(_ context.Context, policy *CleanupPolicy) error {
+	d.log.Info("Пропущено создание политики очистки", "name", policy.Name)
+	return nil
+}
+
+func (d *dryRunAPI) UpdateCleanupPolicy(_ context.Context, name string, _ *CleanupPolicy) error {
+	d.log.Info("Пропущено обновление политики очистки", "name", name)
+	return nil
+}
+
+func (d *dryRunAPI) DeleteCleanupPolicy(_ context.Context, name string) error {
+	d.log.Info("Пропущено удаление политики очистки", "name", name)
+	return nil
+}
+
+func (d *dryRunAPI) CreateBlobStore(_ context.Context, store *BlobStore) error {
+	d.log.Info("Пропущено создание хранилища blob-объектов", "type", store.Type, "name", store.Name)
+	return nil
+}
+
+func (d *dryRunAPI) UpdateBlobStore(_ context.Context, name string, _ *BlobStore) error {
+	d.log.Info("Пропущено обновление хранилища blob-объектов", "name", name)
+	return nil
+}
+
+func (d *dryRunAPI) DeleteBlobStore(_ context.Context, name string) error {
+	d.log.Info("Пропущено удаление хранилища blob-объектов", "name", name)
+	return nil
+}
+
+func (d *dryRunAPI) CreateRoutingRule(_ context.Context, rule *RoutingRule) error {
+	d.log.Info("Пропущено создание правила маршрутизации", "name", rule.Name)
+	return nil
+}
+
+func (d *dryRunAPI) UpdateRoutingRule(_ context.Context, name string, _ *RoutingRule) error {
+	d.log.Info("Пропущено обновление правила маршрутизации", "name", name)
+	return nil
+}
+
+func (d *dryRunAPI) DeleteRoutingRule(_ context.Context, name string) error {
+	d.log.Info("Пропущено удаление правила маршрутизации", "name", name)
+	return nil
+}
@@ -0,0 +1,128 @@
+// Ошибки API Sonatype Nexus
+package nexus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// maxErrorBodyLength ограничивает длину тела ответа в тексте ошибки.
+const maxErrorBodyLength = 1024
+
+// APIError описывает неуспешный ответ API Nexus.
+type APIError struct {
+	// StatusCode - HTTP-статус ответа.
+	StatusCode int
+	// Method и Endpoint - метод и путь запроса.
+	Method   string
+	Endpoint string
+	// Message - текст ошибки из тела ответа Nexus.
+	Message string
+	// Retryable - признак временной ошибки, запрос можно повторить.
+	Retryable bool
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s: %s %s: статус %d", ErrUnexpectedResponse, e.Method, e.Endpoint, e.StatusCode)
+	if e.Message != "" {
+		msg += ", текст: " + e.Message
+	}
+	return msg
+}
+
+// Unwrap позволяет проверять ошибку через errors.Is(err, ErrUnexpectedResponse).
+func (e *APIError) Unwrap() error {
+	return ErrUnexpectedResponse
+}
+
+// NewAPIError создаёт ошибку по ответу Nexus.
+func NewAPIError(resp *resty.Response) error {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode(),
+		Message:    errorMessage(resp.Body()),
+		Retryable:  IsRetryableStatus(resp.StatusCode()),
+	}
+	if req := resp.Request; req != nil {
+		apiErr.Method = req.Method
+		apiErr.Endpoint = req.URL
+		if req.RawRequest != nil {
+			apiErr.Endpoint = req.RawRequest.URL.Path
+		}
+	}
+	return apiErr
+}
+
+// IsRetryableStatus сообщает, является ли статус ответа временной ошибкой (429 и 5xx).
+func IsRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// IsRetryable сообщает, имеет ли смысл повторить операцию, завершившуюся ошибкой err.
+// Ошибки валидации (4xx) считаются окончательными.
+func IsRetryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Retryable
+	}
+	return isTransientError(err)
+}
+
+// StatusCode возвращает HTTP-статус ошибки API или 0, если err не является APIError.
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// isTransientError определяет сетевые ошибки, после которых запрос можно повторить:
+// сброс и отказ соединения, обрыв ответа и таймауты.
+func isTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// errorMessage извлекает текст ошибки из тела ответа Nexus.
+// Ошибки валидации приходят массивом [{"id": "...", "message": "..."}].
+func errorMessage(body []byte) string {
+	var validation []struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &validation); err == nil && len(validation) > 0 {
+		messages := make([]string, 0, len(validation))
+		for _, v := range validation {
+			if v.ID != "" && v.ID != "*" {
+				messages = append(messages, v.ID+": "+v.Message)
+			} else {
+				messages = append(messages, v.Message)
+			}
+		}
+		return strings.Join(messages, "; ")
+	}
+
+	msg := strings.TrimSpace(string(body))
+	if len(msg) > maxErrorBodyLength {
+		msg = msg[:maxErrorBodyLength] + "..."
+	}
+	return msg
+}
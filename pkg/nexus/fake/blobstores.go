@@ -0,0 +1,211 @@
+package fake
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mkostelcev/nexus-operator/pkg/nexus"
+)
+
+const (
+	blobStoresPath = "/service/rest/v1/blobstores"
+	// defaultBlobStore - хранилище, которое Nexus создаёт при установке.
+	defaultBlobStore = "default"
+)
+
+// blobStoreSummary - элемент списка хранилищ.
+type blobStoreSummary struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// BlobStore возвращает копию хранилища из состояния сервера.
+func (s *Server) BlobStore(name string) (*nexus.BlobStore, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	store, ok := s.blobStores[name]
+	if !ok {
+		return nil, false
+	}
+	c := *store
+	return &c, true
+}
+
+// AddBlobStore добавляет хранилище в состояние сервера без валидации.
+func (s *Server) AddBlobStore(store nexus.BlobStore) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blobStores[store.Name] = &store
+}
+
+func (s *Server) registerBlobStores(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+blobStoresPath, func(w http.ResponseWriter, _ *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		stores := make([]blobStoreSummary, 0, len(s.blobStores))
+		for _, store := range s.blobStores {
+			stores = append(stores, blobStoreSummary{Name: store.Name, Type: store.Type})
+		}
+		writeJSON(w, http.StatusOK, stores)
+	})
+
+	mux.HandleFunc("GET "+blobStoresPath+"/{type}/{name}", func(w http.ResponseWriter, r *http.Request) {
+		storeType, ok := blobStoreType(r)
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		store, ok := s.blobStores[r.PathValue("name")]
+		if !ok || store.Type != storeType {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// Как и Nexus, сервер не возвращает секретный ключ S3.
+		c := *store
+		if bucket := c.BucketConfiguration; bucket != nil && bucket.BucketSecurity != nil {
+			config, security := *bucket, *bucket.BucketSecurity
+			security.SecretAccessKey = ""
+			config.BucketSecurity = &security
+			c.BucketConfiguration = &config
+		}
+		writeJSON(w, http.StatusOK, &c)
+	})
+
+	mux.HandleFunc("DELETE "+blobStoresPath+"/{name}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.writable(w) {
+			return
+		}
+		name := r.PathValue("name")
+		if _, ok := s.blobStores[name]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		for _, repo := range s.repositories {
+			if repo.Storage != nil && repo.Storage.BlobStoreName == name {
+				writeError(w, http.StatusBadRequest, "Blob store ("+name+") is in use by repository "+repo.Name)
+				return
+			}
+		}
+		delete(s.blobStores, name)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("POST "+blobStoresPath+"/{type}", func(w http.ResponseWriter, r *http.Request) {
+		store, ok := decodeBlobStore(w, r)
+		if !ok {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.writable(w) {
+			return
+		}
+		if _, exists := s.blobStores[store.Name]; exists {
+			writeError(w, http.StatusBadRequest, "A blob store with the name '"+store.Name+"' already exists")
+			return
+		}
+		s.blobStores[store.Name] = store
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("PUT "+blobStoresPath+"/{type}/{name}", func(w http.ResponseWriter, r *http.Request) {
+		store, ok := decodeBlobStore(w, r)
+		if !ok {
+			return
+		}
+		name := r.PathValue("name")
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.writable(w) {
+			return
+		}
+		current, exists := s.blobStores[name]
+		if !exists || current.Type != store.Type {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		store.Name = name
+		// Секретный ключ S3 можно не передавать при обновлении.
+		if security := bucketSecurity(store); security != nil && security.SecretAccessKey == "" {
+			if previous := bucketSecurity(current); previous != nil {
+				security.SecretAccessKey = previous.SecretAccessKey
+			}
+		}
+		s.blobStores[name] = store
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// decodeBlobStore разбирает и проверяет хранилище; тип берётся из пути запроса.
+func decodeBlobStore(w http.ResponseWriter, r *http.Request) (*nexus.BlobStore, bool) {
+	storeType, ok := blobStoreType(r)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return nil, false
+	}
+
+	store := &nexus.BlobStore{}
+	if !decode(w, r, store) {
+		return nil, false
+	}
+	store.Type = storeType
+
+	var v validator
+	if r.Method == http.MethodPost {
+		v.require(store.Name != "", "PARAMETER name", "may not be empty")
+		v.require(store.Name == "" || namePattern.MatchString(store.Name), "PARAMETER name",
+			"Only letters, digits, underscores(_), hyphens(-), and dots(.) are allowed and may not start with underscore or dot.")
+	}
+	if quota := store.SoftQuota; quota != nil {
+		v.oneOf(quota.Type, "PARAMETER softQuota.type", "spaceRemainingQuota", "spaceUsedQuota")
+		v.require(quota.Limit > 0, "PARAMETER softQuota.limit", "must be greater than 0")
+	}
+	switch storeType {
+	case nexus.BlobStoreTypeFile:
+		v.require(store.Path != "", "PARAMETER path", "may not be empty")
+	case nexus.BlobStoreTypeS3:
+		bucket := store.BucketConfiguration
+		v.require(bucket != nil && bucket.Bucket.Name != "", "PARAMETER bucketConfiguration.bucket.name", "may not be empty")
+		v.require(bucket != nil && bucket.Bucket.Region != "", "PARAMETER bucketConfiguration.bucket.region", "may not be empty")
+		if security := bucketSecurity(store); security != nil && r.Method == http.MethodPost {
+			v.require(security.AccessKeyID != "" && security.SecretAccessKey != "",
+				"PARAMETER bucketConfiguration.bucketSecurity", "Access key ID and secret access key must be specified together")
+		}
+	}
+	if v.write(w) {
+		return nil, false
+	}
+	return store, true
+}
+
+// blobStoreType возвращает тип хранилища по сегменту пути (file или s3).
+func blobStoreType(r *http.Request) (string, bool) {
+	switch strings.ToLower(r.PathValue("type")) {
+	case "file":
+		return nexus.BlobStoreTypeFile, true
+	case "s3":
+		return nexus.BlobStoreTypeS3, true
+	default:
+		return "", false
+	}
+}
+
+func bucketSecurity(store *nexus.BlobStore) *nexus.S3BucketSecurity {
+	if store.BucketConfiguration == nil {
+		return nil
+	}
+	return store.BucketConfiguration.BucketSecurity
+}
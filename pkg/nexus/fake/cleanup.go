@@ -0,0 +1,131 @@
+package fake
+
+import (
+	"net/http"
+	"regexp"
+	"slices"
+
+	"github.com/mkostelcev/nexus-operator/pkg/nexus"
+)
+
+const cleanupPoliciesPath = "/service/rest/v1/cleanup-policies"
+
+// CleanupPolicy возвращает копию политики очистки из состояния сервера.
+func (s *Server) CleanupPolicy(name string) (*nexus.CleanupPolicy, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.cleanupPolicies[name]
+	if !ok {
+		return nil, false
+	}
+	c := *p
+	return &c, true
+}
+
+// AddCleanupPolicy добавляет политику очистки в состояние сервера без валидации.
+func (s *Server) AddCleanupPolicy(p nexus.CleanupPolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cleanupPolicies[p.Name] = &p
+}
+
+func (s *Server) registerCleanupPolicies(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+cleanupPoliciesPath+"/{name}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		p, ok := s.cleanupPolicies[r.PathValue("name")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, p)
+	})
+
+	mux.HandleFunc("DELETE "+cleanupPoliciesPath+"/{name}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.writable(w) {
+			return
+		}
+		name := r.PathValue("name")
+		if _, ok := s.cleanupPolicies[name]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// Nexus снимает удалённую политику со всех репозиториев
+		for _, repo := range s.repositories {
+			if repo.Cleanup != nil {
+				repo.Cleanup.PolicyNames = slices.DeleteFunc(repo.Cleanup.PolicyNames, func(n string) bool {
+					return n == name
+				})
+			}
+		}
+		delete(s.cleanupPolicies, name)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("POST "+cleanupPoliciesPath, func(w http.ResponseWriter, r *http.Request) {
+		p := &nexus.CleanupPolicy{}
+		if !decode(w, r, p) || !validateCleanupPolicy(w, p) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.writable(w) {
+			return
+		}
+		if _, exists := s.cleanupPolicies[p.Name]; exists {
+			writeError(w, http.StatusBadRequest, "Cleanup policy with name '"+p.Name+"' already exists")
+			return
+		}
+		s.cleanupPolicies[p.Name] = p
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	mux.HandleFunc("PUT "+cleanupPoliciesPath+"/{name}", func(w http.ResponseWriter, r *http.Request) {
+		p := &nexus.CleanupPolicy{}
+		if !decode(w, r, p) {
+			return
+		}
+		// Имя при обновлении берётся из пути запроса.
+		p.Name = r.PathValue("name")
+		if !validateCleanupPolicy(w, p) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.writable(w) {
+			return
+		}
+		if _, exists := s.cleanupPolicies[p.Name]; !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		s.cleanupPolicies[p.Name] = p
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func validateCleanupPolicy(w http.ResponseWriter, p *nexus.CleanupPolicy) bool {
+	var v validator
+	v.require(p.Name != "", "PARAMETER name", "may not be empty")
+	v.require(p.Format != "", "PARAMETER format", "may not be empty")
+	v.require(p.CriteriaLastBlobUpdated != nil || p.CriteriaLastDownloaded != nil ||
+		p.CriteriaReleaseType != "" || p.CriteriaAssetRegex != "",
+		"PARAMETER criteria", "At least one criteria must be specified")
+	if p.CriteriaReleaseType != "" {
+		v.oneOf(p.CriteriaReleaseType, "PARAMETER criteriaReleaseType", "RELEASES", "PRERELEASES")
+	}
+	if p.CriteriaAssetRegex != "" {
+		_, err := regexp.Compile(p.CriteriaAssetRegex)
+		v.require(err == nil, "PARAMETER criteriaAssetRegex", "Invalid regular expression")
+	}
+	return !v.write(w)
+}
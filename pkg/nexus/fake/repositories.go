@@ -0,0 +1,381 @@
+package fake
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+
+	"github.com/mkostelcev/nexus-operator/pkg/nexus"
+)
+
+const repositoriesPath = "/service/rest/v1/repositories"
+
+// formatValidator проверяет атрибуты, обязательные для формата.
+type formatValidator func(v *validator, kind string, repo *nexus.Repository)
+
+// repositoryFormat - формат репозитория, поддерживаемый сервером.
+type repositoryFormat struct {
+	// name - название формата в ответах Nexus (например, maven2 для пути maven).
+	name     string
+	validate formatValidator
+	// kinds - поддерживаемые виды репозиториев (пустой - hosted, proxy и group).
+	kinds []string
+}
+
+// formats сопоставляет формат из пути API с его описанием.
+var formats = map[string]repositoryFormat{
+	"maven":  {name: "maven2", validate: validateMaven},
+	"npm":    {name: "npm"},
+	"docker": {name: "docker", validate: validateDocker},
+	"raw":    {name: "raw"},
+	"pypi":   {name: "pypi"},
+	"helm":   {name: "helm", kinds: []string{"hosted", "proxy"}},
+	"apt":    {name: "apt", validate: validateApt, kinds: []string{"hosted", "proxy"}},
+	"yum":    {name: "yum", validate: validateYum},
+	"nuget":  {name: "nuget", validate: validateNuget},
+	"go":     {name: "go", kinds: []string{"proxy", "group"}},
+	"cargo":  {name: "cargo"},
+
+	"rubygems":  {name: "rubygems"},
+	"r":         {name: "r"},
+	"conan":     {name: "conan", kinds: []string{"hosted", "proxy"}},
+	"conda":     {name: "conda", kinds: []string{"proxy"}},
+	"cocoapods": {name: "cocoapods", kinds: []string{"proxy"}},
+	"gitlfs":    {name: "gitlfs", kinds: []string{"hosted"}},
+	"p2":        {name: "p2", kinds: []string{"proxy"}},
+}
+
+// Repository возвращает копию репозитория из состояния сервера.
+func (s *Server) Repository(name string) (*nexus.Repository, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo, ok := s.repositories[name]
+	if !ok {
+		return nil, false
+	}
+	c := *repo
+	return &c, true
+}
+
+// AddRepository добавляет репозиторий в состояние сервера без валидации.
+func (s *Server) AddRepository(format, kind string, repo nexus.Repository) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.storeRepository(format, kind, &repo)
+}
+
+// storeRepository заполняет поля, которые возвращает сервер, и сохраняет репозиторий.
+// Вызывается под s.mu.
+func (s *Server) storeRepository(format, kind string, repo *nexus.Repository) {
+	repo.Format = formats[format].name
+	if repo.Format == "" {
+		repo.Format = format
+	}
+	repo.Type = kind
+	repo.URL = s.URL + "/repository/" + repo.Name
+	// Назначенное правило маршрутизации сервер возвращает в поле routingRuleName.
+	if repo.RoutingRule != "" {
+		repo.RoutingRuleName, repo.RoutingRule = repo.RoutingRule, ""
+	}
+	// Пароль и токен удалённого репозитория сервер не возвращает.
+	if repo.HTTPClient != nil && repo.HTTPClient.Authentication != nil {
+		httpClient, auth := *repo.HTTPClient, *repo.HTTPClient.Authentication
+		auth.Password, auth.BearerToken = "", ""
+		httpClient.Authentication = &auth
+		repo.HTTPClient = &httpClient
+	}
+	s.repositories[repo.Name] = repo
+}
+
+func (s *Server) registerRepositories(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+repositoriesPath, s.listRepositories)
+	mux.HandleFunc("GET "+repositoriesPath+"/{name}", s.getRepository)
+	mux.HandleFunc("DELETE "+repositoriesPath+"/{name}", s.deleteRepository)
+	mux.HandleFunc("POST "+repositoriesPath+"/{format}/{kind}", s.createRepository)
+	mux.HandleFunc("GET "+repositoriesPath+"/{format}/{kind}/{name}", s.getFormatRepository)
+	mux.HandleFunc("PUT "+repositoriesPath+"/{format}/{kind}/{name}", s.updateRepository)
+}
+
+func (s *Server) listRepositories(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repos := make([]nexus.Repository, 0, len(s.repositories))
+	for _, repo := range s.repositories {
+		repos = append(repos, nexus.Repository{Name: repo.Name, Online: repo.Online, Format: repo.Format, Type: repo.Type, URL: repo.URL})
+	}
+	sort.Slice(repos, func(i, j int) bool { return repos[i].Name < repos[j].Name })
+	writeJSON(w, http.StatusOK, repos)
+}
+
+// getRepository отвечает на общий запрос: как и Nexus, без атрибутов формата.
+func (s *Server) getRepository(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo, ok := s.repositories[r.PathValue("name")]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, nexus.Repository{Name: repo.Name, Online: repo.Online, Format: repo.Format, Type: repo.Type, URL: repo.URL})
+}
+
+func (s *Server) getFormatRepository(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo, ok := s.repositories[r.PathValue("name")]
+	if !ok || !matches(repo, r.PathValue("format"), r.PathValue("kind")) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	// Как и Nexus, ключи подписи не возвращаются.
+	c := *repo
+	c.AptSigning, c.YumSigning = nil, nil
+	writeJSON(w, http.StatusOK, c)
+}
+
+func (s *Server) createRepository(w http.ResponseWriter, r *http.Request) {
+	format, kind := r.PathValue("format"), r.PathValue("kind")
+	if f, ok := formats[format]; !ok || !f.supports(kind) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	repo := &nexus.Repository{}
+	if !decode(w, r, repo) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.writable(w) {
+		return
+	}
+	v := s.validateRepository(format, kind, repo)
+	if _, exists := s.repositories[repo.Name]; exists {
+		v.require(false, "PARAMETER name", "Name is already used, must be unique (ignoring case)")
+	}
+	if v.write(w) {
+		return
+	}
+
+	s.storeRepository(format, kind, repo)
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) updateRepository(w http.ResponseWriter, r *http.Request) {
+	format, kind, name := r.PathValue("format"), r.PathValue("kind"), r.PathValue("name")
+
+	repo := &nexus.Repository{}
+	if !decode(w, r, repo) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.writable(w) {
+		return
+	}
+	current, ok := s.repositories[name]
+	if !ok || !matches(current, format, kind) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	v := s.validateRepository(format, kind, repo)
+	v.require(repo.Name == name, "PARAMETER name", "Renaming a repository is not supported")
+	if v.write(w) {
+		return
+	}
+
+	s.storeRepository(format, kind, repo)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteRepository(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.writable(w) {
+		return
+	}
+	name := r.PathValue("name")
+	if _, ok := s.repositories[name]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	delete(s.repositories, name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validateRepository проверяет общие и форматные поля репозитория. Вызывается под s.mu.
+func (s *Server) validateRepository(format, kind string, repo *nexus.Repository) validator {
+	var v validator
+	v.require(repo.Name != "", "PARAMETER name", "may not be empty")
+	v.require(repo.Name == "" || namePattern.MatchString(repo.Name), "PARAMETER name",
+		"Only letters, digits, underscores(_), hyphens(-), and dots(.) are allowed and may not start with underscore or dot.")
+	v.require(repo.Storage != nil && repo.Storage.BlobStoreName != "", "PARAMETER storage.blobStoreName", "may not be empty")
+	if repo.Storage != nil && repo.Storage.BlobStoreName != "" {
+		_, ok := s.blobStores[repo.Storage.BlobStoreName]
+		v.require(ok, "PARAMETER storage.blobStoreName", "Blob store not found: "+repo.Storage.BlobStoreName)
+	}
+
+	switch kind {
+	case "hosted":
+		if repo.Storage != nil {
+			v.oneOf(repo.Storage.WritePolicy, "PARAMETER storage.writePolicy", "ALLOW", "ALLOW_ONCE", "DENY")
+		}
+	case "proxy":
+		v.require(repo.Proxy != nil && repo.Proxy.RemoteURL != "", "PARAMETER proxy.remoteUrl", "may not be empty")
+		v.require(repo.NegativeCache != nil, "PARAMETER negativeCache", "may not be null")
+		v.require(repo.HTTPClient != nil, "PARAMETER httpClient", "may not be null")
+		if repo.HTTPClient != nil {
+			validateHTTPClient(&v, repo.HTTPClient)
+		}
+	case "group":
+		v.require(repo.Group != nil && len(repo.Group.MemberNames) > 0, "PARAMETER group.memberNames", "may not be empty")
+		if repo.Group != nil {
+			for _, member := range repo.Group.MemberNames {
+				m, ok := s.repositories[member]
+				v.require(ok, "PARAMETER group.memberNames", "Repository not found: "+member)
+				if ok {
+					v.require(m.Format == formats[format].name, "PARAMETER group.memberNames",
+						"Member repository format does not match group format: "+member)
+				}
+			}
+			if writable := repo.Group.WritableMember; writable != "" {
+				v.require(slices.Contains(repo.Group.MemberNames, writable), "PARAMETER group.writableMember",
+					"Writable member must be a member of the group: "+writable)
+				m, ok := s.repositories[writable]
+				v.require(!ok || m.Type == "hosted", "PARAMETER group.writableMember",
+					"Writable member must be a hosted repository: "+writable)
+			}
+		}
+	}
+
+	if repo.RoutingRule != "" {
+		_, ok := s.routingRules[repo.RoutingRule]
+		v.require(ok, "PARAMETER routingRule", "Routing rule not found: "+repo.RoutingRule)
+		v.require(kind != "hosted", "PARAMETER routingRule", "Routing rules are not supported by hosted repositories")
+	}
+	if repo.Cleanup != nil {
+		for _, name := range repo.Cleanup.PolicyNames {
+			policy, ok := s.cleanupPolicies[name]
+			v.require(ok, "PARAMETER cleanup.policyNames", "Cleanup policy not found: "+name)
+			if ok {
+				v.require(policy.Format == formats[format].name || policy.Format == "*", "PARAMETER cleanup.policyNames",
+					"Cleanup policy format does not match repository format: "+name)
+			}
+		}
+	}
+
+	if validate := formats[format].validate; validate != nil {
+		validate(&v, kind, repo)
+	}
+	return v
+}
+
+func validateMaven(v *validator, kind string, repo *nexus.Repository) {
+	if kind == "group" {
+		return
+	}
+	v.require(repo.Maven != nil, "PARAMETER maven", "may not be null")
+	if repo.Maven != nil {
+		v.oneOf(repo.Maven.VersionPolicy, "PARAMETER maven.versionPolicy", "RELEASE", "SNAPSHOT", "MIXED")
+		v.oneOf(repo.Maven.LayoutPolicy, "PARAMETER maven.layoutPolicy", "STRICT", "PERMISSIVE")
+	}
+}
+
+func validateDocker(v *validator, kind string, repo *nexus.Repository) {
+	v.require(repo.Docker != nil, "PARAMETER docker", "may not be null")
+	if kind == "proxy" {
+		v.require(repo.DockerProxy != nil, "PARAMETER dockerProxy", "may not be null")
+		if repo.DockerProxy != nil {
+			v.oneOf(repo.DockerProxy.IndexType, "PARAMETER dockerProxy.indexType", "REGISTRY", "HUB", "CUSTOM")
+			v.require(repo.DockerProxy.IndexType != "CUSTOM" || repo.DockerProxy.IndexURL != "",
+				"PARAMETER dockerProxy.indexUrl", "may not be empty when indexType is CUSTOM")
+		}
+	}
+}
+
+func validateApt(v *validator, kind string, repo *nexus.Repository) {
+	v.require(repo.Apt != nil && repo.Apt.Distribution != "", "PARAMETER apt.distribution", "may not be empty")
+	switch kind {
+	case "hosted":
+		v.require(repo.AptSigning != nil && repo.AptSigning.Keypair != "", "PARAMETER aptSigning.keypair", "may not be empty")
+	case "proxy":
+		v.require(repo.Apt != nil && repo.Apt.Flat != nil, "PARAMETER apt.flat", "may not be null")
+	}
+}
+
+func validateYum(v *validator, kind string, repo *nexus.Repository) {
+	if kind != "hosted" {
+		return
+	}
+	v.require(repo.Yum != nil, "PARAMETER yum", "may not be null")
+	if repo.Yum != nil {
+		v.require(repo.Yum.RepodataDepth >= 0 && repo.Yum.RepodataDepth <= 5, "PARAMETER yum.repodataDepth",
+			"must be between 0 and 5")
+		if repo.Yum.DeployPolicy != "" {
+			v.oneOf(repo.Yum.DeployPolicy, "PARAMETER yum.deployPolicy", "STRICT", "PERMISSIVE")
+		}
+	}
+}
+
+func validateNuget(v *validator, kind string, repo *nexus.Repository) {
+	if kind != "proxy" {
+		return
+	}
+	v.require(repo.NugetProxy != nil, "PARAMETER nugetProxy", "may not be null")
+	if repo.NugetProxy != nil {
+		v.oneOf(repo.NugetProxy.NugetVersion, "PARAMETER nugetProxy.nugetVersion", "V2", "V3")
+	}
+}
+
+// supports сообщает, поддерживает ли формат указанный вид репозитория.
+func (f repositoryFormat) supports(kind string) bool {
+	if len(f.kinds) == 0 {
+		return kind == "hosted" || kind == "proxy" || kind == "group"
+	}
+	for _, k := range f.kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// matches сообщает, соответствует ли репозиторий формату и виду из пути запроса.
+func matches(repo *nexus.Repository, format, kind string) bool {
+	f, ok := formats[format]
+	return ok && repo.Format == f.name && repo.Type == kind
+}
+
+func validateHTTPClient(v *validator, httpClient *nexus.RepositoryHTTPClient) {
+	if conn := httpClient.Connection; conn != nil {
+		v.require(conn.Retries == nil || *conn.Retries >= 0 && *conn.Retries <= 10,
+			"PARAMETER httpClient.connection.retries", "must be between 0 and 10")
+		v.require(conn.Timeout == nil || *conn.Timeout >= 1 && *conn.Timeout <= 3600,
+			"PARAMETER httpClient.connection.timeout", "must be between 1 and 3600")
+	}
+
+	auth := httpClient.Authentication
+	if auth == nil {
+		return
+	}
+	v.oneOf(auth.Type, "PARAMETER httpClient.authentication.type", "username", "ntlm", "bearerToken")
+	switch auth.Type {
+	case "username", "ntlm":
+		v.require(auth.Username != "", "PARAMETER httpClient.authentication.username", "may not be empty")
+		if auth.Type == "ntlm" {
+			v.require(auth.NtlmHost != "", "PARAMETER httpClient.authentication.ntlmHost", "may not be empty")
+			v.require(auth.NtlmDomain != "", "PARAMETER httpClient.authentication.ntlmDomain", "may not be empty")
+		}
+	case "bearerToken":
+		v.require(auth.BearerToken != "", "PARAMETER httpClient.authentication.bearerToken", "may not be empty")
+	}
+}
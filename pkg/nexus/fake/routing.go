@@ -0,0 +1,129 @@
+package fake
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/mkostelcev/nexus-operator/pkg/nexus"
+)
+
+const routingRulesPath = "/service/rest/v1/routing-rules"
+
+// RoutingRule возвращает копию правила маршрутизации из состояния сервера.
+func (s *Server) RoutingRule(name string) (*nexus.RoutingRule, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rule, ok := s.routingRules[name]
+	if !ok {
+		return nil, false
+	}
+	c := *rule
+	return &c, true
+}
+
+// AddRoutingRule добавляет правило маршрутизации в состояние сервера без валидации.
+func (s *Server) AddRoutingRule(rule nexus.RoutingRule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.routingRules[rule.Name] = &rule
+}
+
+func (s *Server) registerRoutingRules(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+routingRulesPath+"/{name}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		rule, ok := s.routingRules[r.PathValue("name")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, rule)
+	})
+
+	mux.HandleFunc("DELETE "+routingRulesPath+"/{name}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.writable(w) {
+			return
+		}
+		name := r.PathValue("name")
+		if _, ok := s.routingRules[name]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// Как и Nexus, сервер не удаляет правило, назначенное репозиториям.
+		inUse := 0
+		for _, repo := range s.repositories {
+			if repo.RoutingRuleName == name {
+				inUse++
+			}
+		}
+		if inUse > 0 {
+			writeError(w, http.StatusBadRequest, "Routing rule is still in use by "+strconv.Itoa(inUse)+" repositories.")
+			return
+		}
+		delete(s.routingRules, name)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("POST "+routingRulesPath, func(w http.ResponseWriter, r *http.Request) {
+		rule := &nexus.RoutingRule{}
+		if !decode(w, r, rule) || !validateRoutingRule(w, rule) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.writable(w) {
+			return
+		}
+		if _, exists := s.routingRules[rule.Name]; exists {
+			writeError(w, http.StatusBadRequest, "A routing rule with the same name already exists. Name must be unique.")
+			return
+		}
+		s.routingRules[rule.Name] = rule
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("PUT "+routingRulesPath+"/{name}", func(w http.ResponseWriter, r *http.Request) {
+		rule := &nexus.RoutingRule{}
+		if !decode(w, r, rule) {
+			return
+		}
+		// Имя при обновлении берётся из пути запроса.
+		rule.Name = r.PathValue("name")
+		if !validateRoutingRule(w, rule) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.writable(w) {
+			return
+		}
+		if _, exists := s.routingRules[rule.Name]; !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		s.routingRules[rule.Name] = rule
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func validateRoutingRule(w http.ResponseWriter, rule *nexus.RoutingRule) bool {
+	var v validator
+	v.require(rule.Name != "", "PARAMETER name", "may not be empty")
+	v.oneOf(rule.Mode, "PARAMETER mode", "ALLOW", "BLOCK")
+	v.require(len(rule.Matchers) > 0, "PARAMETER matchers", "At least one rule must be specified")
+	for _, matcher := range rule.Matchers {
+		_, err := regexp.Compile(matcher)
+		v.require(matcher != "" && err == nil, "PARAMETER matchers", "Invalid regular expression: "+matcher)
+	}
+	return !v.write(w)
+}
@@ -0,0 +1,398 @@
+package fake
+
+import (
+	"net/http"
+
+	"github.com/mkostelcev/nexus-operator/pkg/nexus"
+)
+
+const (
+	privilegesPath       = "/service/rest/v1/security/privileges"
+	rolesPath            = "/service/rest/v1/security/roles"
+	contentSelectorsPath = "/service/rest/v1/security/content-selectors"
+)
+
+// privilegeValidators проверяют поля, обязательные для типа привилегии.
+var privilegeValidators = map[string]func(v *validator, p *nexus.Privilege){
+	nexus.PrivilegeTypeWildcard: func(v *validator, p *nexus.Privilege) {
+		v.require(p.Pattern != "", "PARAMETER pattern", "may not be empty")
+	},
+	nexus.PrivilegeTypeApplication: func(v *validator, p *nexus.Privilege) {
+		v.require(p.Domain != "", "PARAMETER domain", "may not be empty")
+		v.require(len(p.Actions) > 0, "PARAMETER actions", "may not be empty")
+	},
+	nexus.PrivilegeTypeRepositoryView:  validateRepositoryPrivilege,
+	nexus.PrivilegeTypeRepositoryAdmin: validateRepositoryPrivilege,
+	nexus.PrivilegeTypeRepositoryContentSelector: func(v *validator, p *nexus.Privilege) {
+		validateRepositoryPrivilege(v, p)
+		v.require(p.ContentSelector != "", "PARAMETER contentSelector", "may not be empty")
+	},
+	nexus.PrivilegeTypeScript: func(v *validator, p *nexus.Privilege) {
+		v.require(p.ScriptName != "", "PARAMETER scriptName", "may not be empty")
+		v.require(len(p.Actions) > 0, "PARAMETER actions", "may not be empty")
+	},
+}
+
+func validateRepositoryPrivilege(v *validator, p *nexus.Privilege) {
+	v.require(p.Format != "", "PARAMETER format", "may not be empty")
+	v.require(p.Repository != "", "PARAMETER repository", "may not be empty")
+	v.require(len(p.Actions) > 0, "PARAMETER actions", "may not be empty")
+}
+
+// Privilege возвращает копию привилегии из состояния сервера.
+func (s *Server) Privilege(name string) (*nexus.Privilege, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.privileges[name]
+	if !ok {
+		return nil, false
+	}
+	c := *p
+	return &c, true
+}
+
+// AddPrivilege добавляет привилегию в состояние сервера без валидации.
+func (s *Server) AddPrivilege(p nexus.Privilege) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.privileges[p.Name] = &p
+}
+
+// Role возвращает копию роли из состояния сервера.
+func (s *Server) Role(id string) (*nexus.Role, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	role, ok := s.roles[id]
+	if !ok {
+		return nil, false
+	}
+	c := *role
+	return &c, true
+}
+
+// AddRole добавляет роль в состояние сервера без валидации.
+func (s *Server) AddRole(role nexus.Role) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.roles[role.ID] = &role
+}
+
+// ContentSelector возвращает копию content-selector из состояния сервера.
+func (s *Server) ContentSelector(name string) (*nexus.ContentSelectorResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cs, ok := s.contentSelectors[name]
+	if !ok {
+		return nil, false
+	}
+	c := *cs
+	return &c, true
+}
+
+// AddContentSelector добавляет content-selector в состояние сервера без валидации.
+func (s *Server) AddContentSelector(cs nexus.ContentSelectorResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.contentSelectors[cs.Name] = &cs
+}
+
+func (s *Server) registerPrivileges(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+privilegesPath+"/{name}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		p, ok := s.privileges[r.PathValue("name")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, p)
+	})
+
+	mux.HandleFunc("DELETE "+privilegesPath+"/{name}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.writable(w) {
+			return
+		}
+		name := r.PathValue("name")
+		p, ok := s.privileges[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if p.ReadOnly {
+			writeError(w, http.StatusBadRequest, "Privilege is read only: "+name)
+			return
+		}
+		delete(s.privileges, name)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("POST "+privilegesPath+"/{type}", func(w http.ResponseWriter, r *http.Request) {
+		p, ok := s.decodePrivilege(w, r)
+		if !ok {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.writable(w) {
+			return
+		}
+		if _, exists := s.privileges[p.Name]; exists {
+			writeError(w, http.StatusBadRequest, "Privilege with name '"+p.Name+"' already exists")
+			return
+		}
+		s.privileges[p.Name] = p
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	mux.HandleFunc("PUT "+privilegesPath+"/{type}/{name}", func(w http.ResponseWriter, r *http.Request) {
+		p, ok := s.decodePrivilege(w, r)
+		if !ok {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.writable(w) {
+			return
+		}
+		name := r.PathValue("name")
+		current, exists := s.privileges[name]
+		if !exists || current.Type != p.Type {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if current.ReadOnly {
+			writeError(w, http.StatusBadRequest, "Privilege is read only: "+name)
+			return
+		}
+		delete(s.privileges, name)
+		s.privileges[p.Name] = p
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// decodePrivilege разбирает и проверяет привилегию; тип берётся из пути запроса.
+func (s *Server) decodePrivilege(w http.ResponseWriter, r *http.Request) (*nexus.Privilege, bool) {
+	privilegeType := r.PathValue("type")
+	validate, ok := privilegeValidators[privilegeType]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return nil, false
+	}
+
+	p := &nexus.Privilege{}
+	if !decode(w, r, p) {
+		return nil, false
+	}
+	p.Type, p.ReadOnly = privilegeType, false
+
+	var v validator
+	v.require(p.Name != "", "PARAMETER name", "may not be empty")
+	validate(&v, p)
+	if v.write(w) {
+		return nil, false
+	}
+	return p, true
+}
+
+func (s *Server) registerRoles(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+rolesPath+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		role, ok := s.roles[r.PathValue("id")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, role)
+	})
+
+	mux.HandleFunc("DELETE "+rolesPath+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.writable(w) {
+			return
+		}
+		id := r.PathValue("id")
+		if _, ok := s.roles[id]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(s.roles, id)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("POST "+rolesPath, func(w http.ResponseWriter, r *http.Request) {
+		role, ok := s.decodeRole(w, r)
+		if !ok {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.writable(w) {
+			return
+		}
+		if _, exists := s.roles[role.ID]; exists {
+			writeError(w, http.StatusBadRequest, "Role with id '"+role.ID+"' already exists")
+			return
+		}
+		if !s.validateRoleReferences(w, role) {
+			return
+		}
+		s.roles[role.ID] = role
+		writeJSON(w, http.StatusCreated, role)
+	})
+
+	mux.HandleFunc("PUT "+rolesPath+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		role, ok := s.decodeRole(w, r)
+		if !ok {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.writable(w) {
+			return
+		}
+		id := r.PathValue("id")
+		if _, exists := s.roles[id]; !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if role.ID != id {
+			writeError(w, http.StatusBadRequest, "The role id in the path does not match the role id in the body")
+			return
+		}
+		if !s.validateRoleReferences(w, role) {
+			return
+		}
+		s.roles[id] = role
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func (s *Server) decodeRole(w http.ResponseWriter, r *http.Request) (*nexus.Role, bool) {
+	role := &nexus.Role{}
+	if !decode(w, r, role) {
+		return nil, false
+	}
+
+	var v validator
+	v.require(role.ID != "", "PARAMETER id", "may not be empty")
+	v.require(role.Name != "", "PARAMETER name", "may not be empty")
+	if v.write(w) {
+		return nil, false
+	}
+	return role, true
+}
+
+// validateRoleReferences проверяет, что привилегии и вложенные роли существуют. Вызывается под s.mu.
+func (s *Server) validateRoleReferences(w http.ResponseWriter, role *nexus.Role) bool {
+	var v validator
+	for _, p := range role.Privileges {
+		_, ok := s.privileges[p]
+		v.require(ok, "PARAMETER privileges", "Privilege not found: "+p)
+	}
+	for _, id := range role.Roles {
+		_, ok := s.roles[id]
+		v.require(ok && id != role.ID, "PARAMETER roles", "Role not found: "+id)
+	}
+	return !v.write(w)
+}
+
+func (s *Server) registerContentSelectors(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+contentSelectorsPath+"/{name}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		cs, ok := s.contentSelectors[r.PathValue("name")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, cs)
+	})
+
+	mux.HandleFunc("DELETE "+contentSelectorsPath+"/{name}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.writable(w) {
+			return
+		}
+		name := r.PathValue("name")
+		if _, ok := s.contentSelectors[name]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(s.contentSelectors, name)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("POST "+contentSelectorsPath, func(w http.ResponseWriter, r *http.Request) {
+		cs := &nexus.ContentSelectorResponse{}
+		if !decode(w, r, cs) || !validateContentSelector(w, cs) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.writable(w) {
+			return
+		}
+		if _, exists := s.contentSelectors[cs.Name]; exists {
+			writeError(w, http.StatusBadRequest, "Content selector with name '"+cs.Name+"' already exists")
+			return
+		}
+		s.contentSelectors[cs.Name] = cs
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("PUT "+contentSelectorsPath+"/{name}", func(w http.ResponseWriter, r *http.Request) {
+		cs := &nexus.ContentSelectorResponse{}
+		if !decode(w, r, cs) {
+			return
+		}
+		// Имя при обновлении берётся из пути запроса.
+		cs.Name = r.PathValue("name")
+		if !validateContentSelector(w, cs) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.writable(w) {
+			return
+		}
+		if _, exists := s.contentSelectors[cs.Name]; !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		s.contentSelectors[cs.Name] = cs
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func validateContentSelector(w http.ResponseWriter, cs *nexus.ContentSelectorResponse) bool {
+	var v validator
+	v.require(cs.Name != "", "PARAMETER name", "may not be empty")
+	v.require(cs.Expression != "", "PARAMETER expression", "may not be empty")
+	return !v.write(w)
+}
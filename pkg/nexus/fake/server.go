@@ -0,0 +1,280 @@
+// Package fake реализует in-memory сервер Sonatype Nexus на базе httptest
+// для проверки клиента и контроллеров без живого Nexus.
+package fake
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mkostelcev/nexus-operator/pkg/nexus"
+)
+
+const (
+	defaultUsername = "admin"
+	defaultPassword = "admin123"
+	defaultVersion  = "3.70.1-02"
+	defaultEdition  = nexus.EditionOSS
+)
+
+// Option настраивает Server.
+type Option func(*Server)
+
+// WithCredentials задаёт учётные данные, которые принимает сервер.
+func WithCredentials(username, password string) Option {
+	return func(s *Server) {
+		s.username, s.password = username, password
+	}
+}
+
+// WithVersion задаёт версию и редакцию, которые сервер сообщает в заголовке Server.
+func WithVersion(version, edition string) Option {
+	return func(s *Server) {
+		s.version, s.edition = version, edition
+	}
+}
+
+// Request - запрос, полученный сервером.
+type Request struct {
+	Method string
+	Path   string
+	Body   []byte
+}
+
+// Fault описывает сбой, который сервер имитирует для подходящих запросов.
+type Fault struct {
+	// Method - HTTP-метод (пустой - любой).
+	Method string
+	// PathPrefix - префикс пути запроса (пустой - любой).
+	PathPrefix string
+	// Status - код ответа вместо обработки запроса (0 - запрос обрабатывается).
+	Status int
+	// RetryAfter - значение заголовка Retry-After в секундах.
+	RetryAfter int
+	// Latency - задержка перед ответом.
+	Latency time.Duration
+	// Times - сколько раз применить сбой (0 - без ограничений).
+	Times int
+}
+
+// Server - in-memory сервер Nexus.
+type Server struct {
+	*httptest.Server
+
+	username string
+	password string
+	version  string
+	edition  string
+
+	mu               sync.Mutex
+	readOnly         bool
+	repositories     map[string]*nexus.Repository
+	privileges       map[string]*nexus.Privilege
+	roles            map[string]*nexus.Role
+	contentSelectors map[string]*nexus.ContentSelectorResponse
+	cleanupPolicies  map[string]*nexus.CleanupPolicy
+	blobStores       map[string]*nexus.BlobStore
+	routingRules     map[string]*nexus.RoutingRule
+	faults           []*Fault
+	requests         []Request
+}
+
+// NewServer запускает сервер. Его необходимо остановить вызовом Close.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		username:         defaultUsername,
+		password:         defaultPassword,
+		version:          defaultVersion,
+		edition:          defaultEdition,
+		repositories:     make(map[string]*nexus.Repository),
+		privileges:       make(map[string]*nexus.Privilege),
+		roles:            make(map[string]*nexus.Role),
+		contentSelectors: make(map[string]*nexus.ContentSelectorResponse),
+		cleanupPolicies:  make(map[string]*nexus.CleanupPolicy),
+		routingRules:     make(map[string]*nexus.RoutingRule),
+		blobStores: map[string]*nexus.BlobStore{
+			defaultBlobStore: {Type: nexus.BlobStoreTypeFile, Name: defaultBlobStore, Path: defaultBlobStore},
+		},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	mux := http.NewServeMux()
+	s.registerStatus(mux)
+	s.registerRepositories(mux)
+	s.registerPrivileges(mux)
+	s.registerRoles(mux)
+	s.registerContentSelectors(mux)
+	s.registerCleanupPolicies(mux)
+	s.registerBlobStores(mux)
+	s.registerRoutingRules(mux)
+
+	s.Server = httptest.NewServer(s.middleware(mux))
+	return s
+}
+
+// Config возвращает конфигурацию клиента для подключения к серверу.
+func (s *Server) Config() nexus.Config {
+	return nexus.Config{
+		BaseURL:  s.URL,
+		Username: s.username,
+		Password: s.password,
+	}
+}
+
+// NewClient создаёт клиент Nexus, подключённый к серверу.
+func (s *Server) NewClient() (*nexus.Client, error) {
+	return nexus.NewClientFromConfig(s.Config())
+}
+
+// SetReadOnly переводит сервер в режим только для чтения.
+func (s *Server) SetReadOnly(readOnly bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.readOnly = readOnly
+}
+
+// InjectFault добавляет имитацию сбоя.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f := fault
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults удаляет все имитации сбоев.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests возвращает запросы, полученные сервером.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestCount возвращает число запросов с указанным методом и префиксом пути.
+func (s *Server) RequestCount(method, pathPrefix string) int {
+	count := 0
+	for _, r := range s.Requests() {
+		if (method == "" || r.Method == method) && strings.HasPrefix(r.Path, pathPrefix) {
+			count++
+		}
+	}
+	return count
+}
+
+// ResetRequests очищает журнал запросов.
+func (s *Server) ResetRequests() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = nil
+}
+
+// middleware записывает запросы, применяет сбои и проверяет аутентификацию.
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := readBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		fault := s.record(Request{Method: r.Method, Path: r.URL.Path, Body: body})
+
+		w.Header().Set("Server", "Nexus/"+s.version+" ("+s.edition+")")
+
+		if fault != nil {
+			if fault.Latency > 0 {
+				select {
+				case <-time.After(fault.Latency):
+				case <-r.Context().Done():
+					return
+				}
+			}
+			if fault.Status != 0 {
+				if fault.RetryAfter > 0 {
+					w.Header().Set("Retry-After", strconv.Itoa(fault.RetryAfter))
+				}
+				writeError(w, fault.Status, http.StatusText(fault.Status))
+				return
+			}
+		}
+
+		if username, password, ok := r.BasicAuth(); !ok || username != s.username || password != s.password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// record сохраняет запрос и возвращает применимый к нему сбой.
+func (s *Server) record(req Request) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, req)
+
+	for i, f := range s.faults {
+		if (f.Method != "" && f.Method != req.Method) || !strings.HasPrefix(req.Path, f.PathPrefix) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+func (s *Server) registerStatus(mux *http.ServeMux) {
+	mux.HandleFunc("GET /service/rest/v1/status", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("GET /service/rest/v1/status/writable", func(w http.ResponseWriter, _ *http.Request) {
+		s.mu.Lock()
+		readOnly := s.readOnly
+		s.mu.Unlock()
+
+		if readOnly {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// writable возвращает false и отвечает 503, если сервер в режиме только для чтения.
+// Вызывается под s.mu.
+func (s *Server) writable(w http.ResponseWriter) bool {
+	if s.readOnly {
+		writeError(w, http.StatusServiceUnavailable, "Nexus находится в режиме только для чтения")
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(message))
+}
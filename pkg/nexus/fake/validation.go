@@ -0,0 +1,68 @@
+package fake
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+)
+
+// namePattern - допустимые имена репозиториев, как в Nexus.
+var namePattern = regexp.MustCompile(`^[a-zA-Z0-9\-_.]+$`)
+
+// validationError - ошибка валидации в формате Nexus.
+type validationError struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+}
+
+// validator накапливает ошибки валидации.
+type validator []validationError
+
+func (v *validator) require(ok bool, id, message string) {
+	if !ok {
+		*v = append(*v, validationError{ID: id, Message: message})
+	}
+}
+
+func (v *validator) oneOf(value, id string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	*v = append(*v, validationError{ID: id, Message: fmt.Sprintf("must be one of %v", allowed)})
+}
+
+// write отвечает 400 со списком ошибок, если они есть.
+func (v validator) write(w http.ResponseWriter) bool {
+	if len(v) == 0 {
+		return false
+	}
+	writeJSON(w, http.StatusBadRequest, v)
+	return true
+}
+
+// readBody читает тело запроса и восстанавливает его для обработчика.
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// decode разбирает тело запроса; при ошибке отвечает 400.
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeJSON(w, http.StatusBadRequest, []validationError{{ID: "*", Message: "Unable to read JSON: " + err.Error()}})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,371 @@
+// Реестр форматов репозиториев Sonatype Nexus
+package nexus
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/mkostelcev/nexus-operator/api/v1alpha1"
+)
+
+// Виды репозиториев.
+const (
+	KindHosted = "hosted"
+	KindProxy  = "proxy"
+	KindGroup  = "group"
+)
+
+// allKinds - виды, которые поддерживает большинство форматов.
+var allKinds = []string{KindHosted, KindProxy, KindGroup}
+
+// commonRequired - секции спецификации, обязательные для вида репозитория независимо от формата.
+var commonRequired = map[string][]string{
+	KindProxy: {"proxy"},
+	KindGroup: {"group"},
+}
+
+// RepositoryFormat описывает формат репозитория: поддерживаемые виды, обязательные
+// секции спецификации и построение атрибутов формата. Тип репозитория имеет вид
+// <формат>-<вид>, а путь API - <формат>/<вид>.
+type RepositoryFormat struct {
+	// Name - формат в типе репозитория и в пути API Nexus, например maven.
+	Name string
+	// Kinds - поддерживаемые виды репозиториев.
+	Kinds []string
+	// Required - секции спецификации (по имени JSON-поля), обязательные для вида репозитория.
+	Required map[string][]string
+	// Build заполняет атрибуты формата, подставляя значения по умолчанию для секций,
+	// обязательных в API Nexus. Nil, если у формата нет собственных атрибутов.
+	Build func(spec *v1alpha1.RepositorySpec, kind string, secrets RepositorySecrets, config *Repository)
+	// Capabilities возвращает используемые спецификацией возможности Nexus, зависящие от версии.
+	Capabilities func(spec *v1alpha1.RepositorySpec) []Capability
+	// CheckRemoteURL проверяет адрес удалённого репозитория и возвращает описание ошибки
+	// или пустую строку.
+	CheckRemoteURL func(u *url.URL) string
+	// WritableGroup сообщает, поддерживает ли групповой репозиторий формата публикацию
+	// в участника group.writableMember.
+	WritableGroup bool
+}
+
+// repositoryFormats - зарегистрированные форматы по имени.
+var repositoryFormats = map[string]*RepositoryFormat{}
+
+// RegisterRepositoryFormat регистрирует формат. Повторная регистрация заменяет описание.
+func RegisterRepositoryFormat(format RepositoryFormat) {
+	f := format
+	if len(f.Kinds) == 0 {
+		f.Kinds = allKinds
+	}
+	repositoryFormats[f.Name] = &f
+}
+
+// LookupRepositoryFormat возвращает формат и вид репозитория по его типу, например maven-hosted.
+func LookupRepositoryFormat(repoType string) (*RepositoryFormat, string, error) {
+	i := strings.LastIndex(repoType, "-")
+	if i <= 0 {
+		return nil, "", fmt.Errorf("%w: %s", ErrUnsupportedRepoType, repoType)
+	}
+	format, ok := repositoryFormats[repoType[:i]]
+	kind := repoType[i+1:]
+	if !ok || !format.Supports(kind) {
+		return nil, "", fmt.Errorf("%w: %s", ErrUnsupportedRepoType, repoType)
+	}
+	return format, kind, nil
+}
+
+// RepositoryTypes возвращает все поддерживаемые типы репозиториев в алфавитном порядке.
+func RepositoryTypes() []string {
+	var types []string
+	for name, format := range repositoryFormats {
+		for _, kind := range format.Kinds {
+			types = append(types, name+"-"+kind)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Supports сообщает, поддерживает ли формат вид репозитория.
+func (f *RepositoryFormat) Supports(kind string) bool {
+	for _, k := range f.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// endpoint возвращает адрес API для репозитория указанного вида.
+func (f *RepositoryFormat) endpoint(kind string) string {
+	return repositoriesAPIPath + "/" + f.Name + "/" + kind
+}
+
+// validate проверяет, что в спецификации заданы секции, обязательные для формата и вида.
+func (f *RepositoryFormat) validate(spec *v1alpha1.RepositorySpec, kind string) error {
+	var missing []string
+	for _, section := range append(commonRequired[kind], f.Required[kind]...) {
+		if !hasSpecSection(spec, section) {
+			missing = append(missing, section)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: для типа %s требуются секции %s",
+			ErrInvalidRepositorySpec, spec.Type, strings.Join(missing, ", "))
+	}
+
+	if kind == KindGroup && spec.Group.WritableMember != "" {
+		if !f.WritableGroup {
+			return fmt.Errorf("%w: group.writableMember не поддерживается для типа %s",
+				ErrInvalidRepositorySpec, spec.Type)
+		}
+		if !slices.Contains(spec.Group.MemberNames, spec.Group.WritableMember) {
+			return fmt.Errorf("%w: group.writableMember %s не входит в group.memberNames",
+				ErrInvalidRepositorySpec, spec.Group.WritableMember)
+		}
+	}
+	return nil
+}
+
+// specSections сопоставляет имя JSON-поля секции спецификации с индексом поля структуры.
+var specSections = func() map[string]int {
+	sections := make(map[string]int)
+	t := reflect.TypeOf(v1alpha1.RepositorySpec{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Type.Kind() != reflect.Ptr {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		sections[name] = i
+	}
+	return sections
+}()
+
+// hasSpecSection сообщает, задана ли в спецификации секция с указанным именем JSON-поля.
+func hasSpecSection(spec *v1alpha1.RepositorySpec, name string) bool {
+	i, ok := specSections[name]
+	return ok && !reflect.ValueOf(spec).Elem().Field(i).IsNil()
+}
+
+func init() {
+	RegisterRepositoryFormat(RepositoryFormat{
+		Name:     "maven",
+		Required: map[string][]string{KindHosted: {"maven"}, KindProxy: {"maven"}},
+		Build:    buildMaven,
+	})
+	RegisterRepositoryFormat(RepositoryFormat{
+		Name:         "npm",
+		Build:        buildNpm,
+		Capabilities: npmCapabilities,
+	})
+	RegisterRepositoryFormat(RepositoryFormat{
+		Name:          "docker",
+		Build:         buildDocker,
+		Capabilities:  dockerCapabilities,
+		WritableGroup: true,
+	})
+	RegisterRepositoryFormat(RepositoryFormat{
+		Name:  "raw",
+		Build: buildRaw,
+	})
+	RegisterRepositoryFormat(RepositoryFormat{
+		Name:         "pypi",
+		Build:        buildPypi,
+		Capabilities: pypiCapabilities,
+	})
+	RegisterRepositoryFormat(RepositoryFormat{
+		Name:  "helm",
+		Kinds: []string{KindHosted, KindProxy},
+	})
+	RegisterRepositoryFormat(RepositoryFormat{
+		Name:     "apt",
+		Kinds:    []string{KindHosted, KindProxy},
+		Required: map[string][]string{KindHosted: {"apt", "signing"}, KindProxy: {"apt"}},
+		Build:    buildApt,
+	})
+	RegisterRepositoryFormat(RepositoryFormat{
+		Name:     "yum",
+		Required: map[string][]string{KindHosted: {"yum"}},
+		Build:    buildYum,
+	})
+	RegisterRepositoryFormat(RepositoryFormat{
+		Name:  "nuget",
+		Build: buildNuget,
+	})
+	RegisterRepositoryFormat(RepositoryFormat{
+		Name:           "go",
+		Kinds:          []string{KindProxy, KindGroup},
+		CheckRemoteURL: checkGoProxyURL,
+	})
+	RegisterRepositoryFormat(RepositoryFormat{
+		Name:           "cargo",
+		Capabilities:   func(*v1alpha1.RepositorySpec) []Capability { return []Capability{CapabilityCargo} },
+		CheckRemoteURL: checkCargoProxyURL,
+	})
+
+	// Форматы без собственных атрибутов
+	RegisterRepositoryFormat(RepositoryFormat{Name: "rubygems"})
+	RegisterRepositoryFormat(RepositoryFormat{Name: "r"})
+	RegisterRepositoryFormat(RepositoryFormat{Name: "conan", Kinds: []string{KindHosted, KindProxy}})
+	RegisterRepositoryFormat(RepositoryFormat{Name: "conda", Kinds: []string{KindProxy}})
+	RegisterRepositoryFormat(RepositoryFormat{Name: "cocoapods", Kinds: []string{KindProxy}})
+	RegisterRepositoryFormat(RepositoryFormat{Name: "gitlfs", Kinds: []string{KindHosted}})
+	RegisterRepositoryFormat(RepositoryFormat{Name: "p2", Kinds: []string{KindProxy}})
+}
+
+func buildMaven(spec *v1alpha1.RepositorySpec, _ string, _ RepositorySecrets, config *Repository) {
+	if spec.Maven != nil {
+		config.Maven = &MavenAttributes{
+			VersionPolicy:      spec.Maven.VersionPolicy,
+			LayoutPolicy:       spec.Maven.LayoutPolicy,
+			ContentDisposition: spec.Maven.ContentDisposition,
+		}
+	}
+}
+
+func buildNpm(spec *v1alpha1.RepositorySpec, _ string, _ RepositorySecrets, config *Repository) {
+	if spec.Npm != nil {
+		config.Npm = &NpmAttributes{
+			RemoveNonCataloged: spec.Npm.RemoveNonCataloged,
+			RemoveQuarantined:  spec.Npm.RemoveQuarantined,
+		}
+	}
+}
+
+func npmCapabilities(spec *v1alpha1.RepositorySpec) []Capability {
+	var used []Capability
+	if spec.Npm != nil {
+		if spec.Npm.RemoveQuarantined {
+			used = append(used, CapabilityNpmRemoveQuarantined)
+		}
+		if spec.Npm.RemoveNonCataloged {
+			used = append(used, CapabilityNpmRemoveNonCataloged)
+		}
+	}
+	return used
+}
+
+// buildDocker заполняет атрибуты docker. Секция docker обязательна в API Nexus,
+// поэтому без настроек в спецификации отправляются значения по умолчанию.
+func buildDocker(spec *v1alpha1.RepositorySpec, kind string, _ RepositorySecrets, config *Repository) {
+	config.Docker = &DockerAttributes{}
+	if spec.Docker != nil {
+		config.Docker = &DockerAttributes{
+			V1Enabled:      spec.Docker.V1Enabled,
+			ForceBasicAuth: spec.Docker.ForceBasicAuth,
+			HTTPPort:       dockerPort(spec.Docker.HttpPort),
+			HTTPSPort:      dockerPort(spec.Docker.HttpsPort),
+			Subdomain:      spec.Docker.Subdomain,
+			PathEnabled:    spec.Docker.PathEnabled,
+		}
+	}
+
+	switch kind {
+	case KindHosted:
+		latestPolicy := spec.Docker != nil && spec.Docker.LatestPolicy
+		config.Storage.LatestPolicy = &latestPolicy
+	case KindProxy:
+		config.DockerProxy = &DockerProxyAttributes{IndexType: defaultDockerIndexType}
+		if spec.Docker != nil && spec.Docker.Proxy != nil {
+			proxy := spec.Docker.Proxy
+			if proxy.IndexType != "" {
+				config.DockerProxy.IndexType = proxy.IndexType
+			}
+			config.DockerProxy.IndexURL = proxy.IndexUrl
+			config.DockerProxy.CacheForeignLayers = proxy.CacheForeignLayers
+			config.DockerProxy.ForeignLayerURLWhitelist = proxy.ForeignLayerUrlWhitelist
+		}
+	}
+}
+
+// dockerPort возвращает номер порта коннектора. Значение auto должно быть заменено
+// выделенным портом до построения конфигурации, иначе коннектор не настраивается.
+func dockerPort(port *intstr.IntOrString) *int {
+	if port == nil || port.Type != intstr.Int {
+		return nil
+	}
+	value := port.IntValue()
+	return &value
+}
+
+func dockerCapabilities(spec *v1alpha1.RepositorySpec) []Capability {
+	var used []Capability
+	if spec.Docker != nil && spec.Docker.Subdomain != "" {
+		used = append(used, CapabilityDockerSubdomain)
+	}
+	if spec.Group != nil && spec.Group.WritableMember != "" {
+		used = append(used, CapabilityDockerGroupWritableMember)
+	}
+	return used
+}
+
+func buildRaw(spec *v1alpha1.RepositorySpec, _ string, _ RepositorySecrets, config *Repository) {
+	if spec.Raw != nil {
+		config.Raw = &RawAttributes{ContentDisposition: spec.Raw.ContentDisposition}
+	}
+}
+
+func buildPypi(spec *v1alpha1.RepositorySpec, kind string, _ RepositorySecrets, config *Repository) {
+	if kind == KindProxy && spec.Pypi != nil {
+		config.Pypi = &PypiAttributes{RemoveQuarantined: spec.Pypi.RemoveQuarantined}
+	}
+}
+
+func pypiCapabilities(spec *v1alpha1.RepositorySpec) []Capability {
+	if spec.Pypi != nil && spec.Pypi.RemoveQuarantined {
+		return []Capability{CapabilityPypiRemoveQuarantined}
+	}
+	return nil
+}
+
+func buildApt(spec *v1alpha1.RepositorySpec, kind string, secrets RepositorySecrets, config *Repository) {
+	if spec.Apt != nil {
+		config.Apt = &AptAttributes{Distribution: spec.Apt.Distribution}
+		if kind == KindProxy {
+			flat := spec.Apt.Flat
+			config.Apt.Flat = &flat
+		}
+	}
+	if kind == KindHosted && secrets.SigningKey != nil {
+		config.AptSigning = buildSigningConfig(secrets.SigningKey)
+	}
+}
+
+func buildYum(spec *v1alpha1.RepositorySpec, kind string, secrets RepositorySecrets, config *Repository) {
+	if kind == KindHosted && spec.Yum != nil {
+		config.Yum = &YumAttributes{
+			RepodataDepth: spec.Yum.RepodataDepth,
+			DeployPolicy:  spec.Yum.DeployPolicy,
+		}
+	}
+	// API Nexus принимает yumSigning только для proxy- и group-репозиториев.
+	if kind != KindHosted && secrets.SigningKey != nil {
+		config.YumSigning = buildSigningConfig(secrets.SigningKey)
+	}
+}
+
+// buildNuget заполняет атрибуты nuget-proxy. Секция nugetProxy обязательна в API Nexus,
+// поэтому отправляется и без настроек в спецификации.
+func buildNuget(spec *v1alpha1.RepositorySpec, kind string, _ RepositorySecrets, config *Repository) {
+	if kind != KindProxy {
+		return
+	}
+	config.NugetProxy = &NugetProxyAttributes{
+		NugetVersion:         defaultNugetVersion,
+		QueryCacheItemMaxAge: defaultNugetQueryCacheItemMaxAge,
+	}
+	if spec.NugetProxy != nil {
+		if spec.NugetProxy.NugetVersion != "" {
+			config.NugetProxy.NugetVersion = spec.NugetProxy.NugetVersion
+		}
+		if spec.NugetProxy.QueryCacheItemMaxAge != 0 {
+			config.NugetProxy.QueryCacheItemMaxAge = spec.NugetProxy.QueryCacheItemMaxAge
+		}
+	}
+}
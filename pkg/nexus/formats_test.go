@@ -0,0 +1,185 @@
+package nexus_test
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mkostelcev/nexus-operator/api/v1alpha1"
+	"github.com/mkostelcev/nexus-operator/pkg/nexus"
+)
+
+const repositoryTypesFile = "../../api/v1alpha1/repository_types.go"
+
+// TestRepositoryTypeEnumMatchesRegistry проверяет, что перечень типов в CRD Repository
+// совпадает с реестром форматов.
+func TestRepositoryTypeEnumMatchesRegistry(t *testing.T) {
+	enum := repositoryTypeEnum(t)
+	slices.Sort(enum)
+
+	if registered := nexus.RepositoryTypes(); !slices.Equal(enum, registered) {
+		t.Fatalf("перечень типов в CRD не совпадает с реестром форматов:\nCRD:    %v\nреестр: %v", enum, registered)
+	}
+}
+
+// repositoryTypeEnum возвращает значения маркера Enum поля RepositorySpec.Type.
+func repositoryTypeEnum(t *testing.T) []string {
+	t.Helper()
+
+	file, err := parser.ParseFile(token.NewFileSet(), repositoryTypesFile, nil, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("ошибка разбора %s: %v", repositoryTypesFile, err)
+	}
+
+	var enum []string
+	ast.Inspect(file, func(node ast.Node) bool {
+		spec, ok := node.(*ast.TypeSpec)
+		if !ok || spec.Name.Name != "RepositorySpec" {
+			return true
+		}
+		for _, field := range spec.Type.(*ast.StructType).Fields.List {
+			if len(field.Names) == 0 || field.Names[0].Name != "Type" || field.Doc == nil {
+				continue
+			}
+			for _, comment := range field.Doc.List {
+				if values, ok := strings.CutPrefix(comment.Text, "// +kubebuilder:validation:Enum="); ok {
+					enum = strings.Split(values, ";")
+				}
+			}
+		}
+		return false
+	})
+	if len(enum) == 0 {
+		t.Fatalf("в %s не найден маркер Enum поля RepositorySpec.Type", repositoryTypesFile)
+	}
+	return enum
+}
+
+func TestBuildYumSigningOnlyForProxyAndGroup(t *testing.T) {
+	secrets := nexus.RepositorySecrets{SigningKey: &nexus.SigningKey{Keypair: "keypair"}}
+
+	tests := []struct {
+		spec    v1alpha1.RepositorySpec
+		signing bool
+	}{
+		{
+			spec: v1alpha1.RepositorySpec{Name: "yum-hosted", Type: nexus.TypeYumHosted, Yum: &v1alpha1.YumConfig{}},
+		},
+		{
+			spec: v1alpha1.RepositorySpec{
+				Name:  "yum-proxy",
+				Type:  nexus.TypeYumProxy,
+				Proxy: &v1alpha1.ProxyConfig{RemoteUrl: "https://mirror.example.com/centos"},
+			},
+			signing: true,
+		},
+		{
+			spec: v1alpha1.RepositorySpec{
+				Name:  "yum-group",
+				Type:  nexus.TypeYumGroup,
+				Group: &v1alpha1.GroupConfig{MemberNames: []string{"yum-hosted"}},
+			},
+			signing: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec.Type, func(t *testing.T) {
+			config, err := nexus.BuildRepositoryConfig(v1alpha1.Repository{Spec: tt.spec}, secrets)
+			if err != nil {
+				t.Fatalf("BuildRepositoryConfig: %v", err)
+			}
+			if got := config.YumSigning != nil; got != tt.signing {
+				t.Errorf("yumSigning задан: %t, ожидалось %t", got, tt.signing)
+			}
+		})
+	}
+}
+
+func TestLookupRepositoryFormat(t *testing.T) {
+	tests := []struct {
+		repoType string
+		format   string
+		kind     string
+		valid    bool
+	}{
+		{repoType: nexus.TypeMavenHosted, format: "maven", kind: nexus.KindHosted, valid: true},
+		{repoType: "docker-group", format: "docker", kind: nexus.KindGroup, valid: true},
+		{repoType: "go-proxy", format: "go", kind: nexus.KindProxy, valid: true},
+		{repoType: "go-hosted"},
+		{repoType: "helm-group"},
+		{repoType: "unknown-hosted"},
+		{repoType: "maven"},
+		{repoType: "-hosted"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.repoType, func(t *testing.T) {
+			format, kind, err := nexus.LookupRepositoryFormat(tt.repoType)
+			if !tt.valid {
+				if !errors.Is(err, nexus.ErrUnsupportedRepoType) {
+					t.Fatalf("ошибка %v, ожидалась ErrUnsupportedRepoType", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LookupRepositoryFormat: %v", err)
+			}
+			if format.Name != tt.format || kind != tt.kind {
+				t.Errorf("формат %s, вид %s, ожидались %s и %s", format.Name, kind, tt.format, tt.kind)
+			}
+			if !format.Supports(kind) {
+				t.Errorf("формат %s не поддерживает вид %s", format.Name, kind)
+			}
+		})
+	}
+}
+
+func TestBuildRepositoryConfigRequiredSections(t *testing.T) {
+	tests := []struct {
+		name string
+		spec v1alpha1.RepositorySpec
+	}{
+		{
+			name: "proxy без секции proxy",
+			spec: v1alpha1.RepositorySpec{Name: "npm-proxy", Type: "npm-proxy"},
+		},
+		{
+			name: "group без секции group",
+			spec: v1alpha1.RepositorySpec{Name: "npm-group", Type: "npm-group"},
+		},
+		{
+			name: "maven-hosted без секции maven",
+			spec: v1alpha1.RepositorySpec{Name: "maven-releases", Type: nexus.TypeMavenHosted},
+		},
+		{
+			name: "writableMember вне memberNames",
+			spec: v1alpha1.RepositorySpec{
+				Name:  "docker-group",
+				Type:  "docker-group",
+				Group: &v1alpha1.GroupConfig{MemberNames: []string{"docker-hosted"}, WritableMember: "docker-other"},
+			},
+		},
+		{
+			name: "writableMember для формата без поддержки",
+			spec: v1alpha1.RepositorySpec{
+				Name:  "npm-group",
+				Type:  "npm-group",
+				Group: &v1alpha1.GroupConfig{MemberNames: []string{"npm-hosted"}, WritableMember: "npm-hosted"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := nexus.BuildRepositoryConfig(v1alpha1.Repository{Spec: tt.spec}, nexus.RepositorySecrets{})
+			if !errors.Is(err, nexus.ErrInvalidRepositorySpec) {
+				t.Fatalf("ошибка %v, ожидалась ErrInvalidRepositorySpec", err)
+			}
+		})
+	}
+}
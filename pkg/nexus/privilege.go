@@ -3,10 +3,12 @@ package nexus
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
 	"github.com/mkostelcev/nexus-operator/api/v1alpha1"
 )
 
@@ -18,34 +20,29 @@ func (c *Client) PrivilegeExists(ctx context.Context, name string) (bool, error)
 	return err == nil, err
 }
 
-func (c *Client) GetPrivilege(ctx context.Context, name string) (map[string]interface{}, error) {
+func (c *Client) GetPrivilege(ctx context.Context, name string) (*Privilege, error) {
 	resp, err := c.Resty.R().
 		SetContext(ctx).
 		SetPathParam("name", name).
+		SetResult(&Privilege{}).
 		Get("/service/rest/v1/security/privileges/{name}")
-
-	if resp.StatusCode() == 404 {
-		return nil, ErrPrivilegeNotFound
-	}
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(resp.Body(), &result); err != nil {
-		return nil, fmt.Errorf("parse error: %w", err)
+	switch resp.StatusCode() {
+	case 200:
+		return resp.Result().(*Privilege), nil
+	case 404:
+		return nil, ErrPrivilegeNotFound
+	default:
+		return nil, NewAPIError(resp)
 	}
-	return result, nil
 }
 
-func (c *Client) CreatePrivilege(ctx context.Context, config map[string]interface{}) error {
-	privilegeType, ok := config["type"].(string)
-	if !ok {
-		return ErrInvalidPrivilegeType
-	}
-
+func (c *Client) CreatePrivilege(ctx context.Context, config *Privilege) error {
 	var endpoint string
-	switch privilegeType {
+	switch config.Type {
 	case PrivilegeTypeWildcard:
 		endpoint = "/service/rest/v1/security/privileges/wildcard"
 	case PrivilegeTypeApplication:
@@ -59,7 +56,7 @@ func (c *Client) CreatePrivilege(ctx context.Context, config map[string]interfac
 	case PrivilegeTypeScript:
 		endpoint = "/service/rest/v1/security/privileges/script"
 	default:
-		return fmt.Errorf("%w: %s", ErrInvalidPrivilegeType, privilegeType)
+		return fmt.Errorf("%w: %s", ErrInvalidPrivilegeType, config.Type)
 	}
 
 	resp, err := c.Resty.R().
@@ -71,19 +68,14 @@ func (c *Client) CreatePrivilege(ctx context.Context, config map[string]interfac
 	}
 
 	if resp.StatusCode() != 201 {
-		return NewUnexpectedResponseError(resp.StatusCode(), resp.String())
+		return NewAPIError(resp)
 	}
 	return nil
 }
 
-func (c *Client) UpdatePrivilege(ctx context.Context, name string, config map[string]interface{}) error {
-	privilegeType, ok := config["type"].(string)
-	if !ok {
-		return ErrInvalidPrivilegeType
-	}
-
+func (c *Client) UpdatePrivilege(ctx context.Context, name string, config *Privilege) error {
 	var endpoint string
-	switch privilegeType {
+	switch config.Type {
 	case PrivilegeTypeWildcard:
 		endpoint = fmt.Sprintf("/service/rest/v1/security/privileges/wildcard/%s", name)
 	case PrivilegeTypeApplication:
@@ -97,7 +89,7 @@ func (c *Client) UpdatePrivilege(ctx context.Context, name string, config map[st
 	case PrivilegeTypeScript:
 		endpoint = fmt.Sprintf("/service/rest/v1/security/privileges/script/%s", name)
 	default:
-		return fmt.Errorf("%w: %s", ErrInvalidPrivilegeType, privilegeType)
+		return fmt.Errorf("%w: %s", ErrInvalidPrivilegeType, config.Type)
 	}
 
 	resp, err := c.Resty.R().
@@ -109,7 +101,7 @@ func (c *Client) UpdatePrivilege(ctx context.Context, name string, config map[st
 	}
 
 	if resp.StatusCode() != 204 {
-		return NewUnexpectedResponseError(resp.StatusCode(), resp.String())
+		return NewAPIError(resp)
 	}
 	return nil
 }
@@ -124,59 +116,59 @@ func (c *Client) DeletePrivilege(ctx context.Context, name string) error {
 		return ErrPrivilegeNotFound
 	}
 	if resp.StatusCode() != 204 {
-		return NewUnexpectedResponseError(resp.StatusCode(), resp.String())
+		return NewAPIError(resp)
 	}
 	return nil
 }
 
-func BuildPrivilegeConfig(spec v1alpha1.PrivilegeSpec) (map[string]interface{}, error) {
-	config := map[string]interface{}{
-		"name":        spec.Name,
-		"description": spec.Description,
-		"type":        spec.Type,
+func BuildPrivilegeConfig(spec v1alpha1.PrivilegeSpec) (*Privilege, error) {
+	config := &Privilege{
+		Name:        spec.Name,
+		Description: spec.Description,
+		Type:        spec.Type,
 	}
 
 	switch spec.Type {
-	case "wildcard":
+	case PrivilegeTypeWildcard:
 		if spec.Wildcard == nil {
 			return nil, fmt.Errorf("%w", ErrWildcardConfigRequired)
 		}
-		config["pattern"] = spec.Wildcard.Pattern
+		config.Pattern = spec.Wildcard.Pattern
 
-	case "application":
+	case PrivilegeTypeApplication:
 		if spec.Application == nil {
 			return nil, fmt.Errorf("%w", ErrApplicationConfigRequired)
 		}
-		config["domain"] = spec.Application.Domain
-		config["actions"] = spec.Application.Actions
+		config.Domain = spec.Application.Domain
+		config.Actions = spec.Application.Actions
 
-	case "repository-view":
+	case PrivilegeTypeRepositoryView:
 		if spec.RepositoryView == nil {
 			return nil, fmt.Errorf("%w", ErrRepoViewConfigRequired)
 		}
-		config["repository"] = spec.RepositoryView.Repository
-		config["actions"] = spec.RepositoryView.Actions
+		config.Repository = spec.RepositoryView.Repository
+		config.Actions = spec.RepositoryView.Actions
 
-	case "repository-admin":
+	case PrivilegeTypeRepositoryAdmin:
 		if spec.RepositoryAdmin == nil {
 			return nil, fmt.Errorf("%w", ErrRepoAdminConfigRequired)
 		}
-		config["repository"] = spec.RepositoryAdmin.Repository
+		config.Repository = spec.RepositoryAdmin.Repository
 
-	case "repository-content-selector":
+	case PrivilegeTypeRepositoryContentSelector:
 		if spec.RepositoryContentSelector == nil {
 			return nil, fmt.Errorf("%w", ErrRepoContentSelConfigRequired)
 		}
-		config["repository"] = spec.RepositoryContentSelector.Repository
-		config["contentSelector"] = spec.RepositoryContentSelector.ContentSelector
-		config["format"] = spec.RepositoryContentSelector.Format
-		config["actions"] = spec.RepositoryContentSelector.Actions
+		config.Repository = spec.RepositoryContentSelector.Repository
+		config.ContentSelector = spec.RepositoryContentSelector.ContentSelector
+		config.Format = spec.RepositoryContentSelector.Format
+		config.Actions = spec.RepositoryContentSelector.Actions
 
-	case "script":
+	case PrivilegeTypeScript:
 		if spec.Script == nil {
 			return nil, fmt.Errorf("%w", ErrScriptConfigRequired)
 		}
-		config["scriptName"] = spec.Script.ScriptName
+		config.ScriptName = spec.Script.ScriptName
 
 	default:
 		return nil, fmt.Errorf("%w: %s", ErrUnsupportedPrivilegeType, spec.Type)
@@ -184,3 +176,22 @@ func BuildPrivilegeConfig(spec v1alpha1.PrivilegeSpec) (map[string]interface{},
 
 	return config, nil
 }
+
+// PrivilegeDiff возвращает различия между желаемой и текущей привилегией.
+// Пустая строка означает, что обновление не требуется. Не учитываются признак readOnly,
+// порядок действий и поля, которые сервер заполняет сам (format и actions, если они не заданы).
+func PrivilegeDiff(desired, current *Privilege) string {
+	normalized := *current
+	normalized.ReadOnly = false
+	if desired.Format == "" {
+		normalized.Format = ""
+	}
+	if len(desired.Actions) == 0 {
+		normalized.Actions = nil
+	}
+
+	return cmp.Diff(desired, &normalized,
+		cmpopts.EquateEmpty(),
+		cmpopts.SortSlices(func(a, b string) bool { return a < b }),
+	)
+}
@@ -0,0 +1,54 @@
+// Проверка адресов удалённых репозиториев
+package nexus
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ValidateRemoteURL проверяет адрес удалённого репозитория для proxy-репозитория указанного типа.
+func ValidateRemoteURL(repoType, remoteURL string) error {
+	u, err := url.Parse(remoteURL)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrInvalidRemoteURL, remoteURL, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w %q: ожидается абсолютный адрес http или https", ErrInvalidRemoteURL, remoteURL)
+	}
+
+	// Проверка, специфичная для формата, задаётся в его описании
+	if format, _, err := LookupRepositoryFormat(repoType); err == nil && format.CheckRemoteURL != nil {
+		if msg := format.CheckRemoteURL(u); msg != "" {
+			return fmt.Errorf("%w %q: %s", ErrInvalidRemoteURL, remoteURL, msg)
+		}
+	}
+	return nil
+}
+
+// checkGoProxyURL проверяет, что адрес указывает на корень GOPROXY, а не на модуль.
+func checkGoProxyURL(u *url.URL) string {
+	const hint = "ожидается корень GOPROXY, например https://proxy.golang.org"
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "адрес не должен содержать параметры запроса, " + hint
+	}
+	if strings.Contains(u.Path, "/@v/") || strings.HasSuffix(u.Path, "/@v") || strings.HasSuffix(u.Path, "/@latest") {
+		return "адрес указывает на модуль, " + hint
+	}
+	if strings.HasSuffix(u.Path, ".git") {
+		return "адрес указывает на git-репозиторий, " + hint
+	}
+	return ""
+}
+
+// checkCargoProxyURL проверяет, что адрес указывает на sparse-индекс, а не на git-индекс.
+func checkCargoProxyURL(u *url.URL) string {
+	const hint = "ожидается sparse-индекс без префикса sparse+, например https://index.crates.io/"
+	if strings.HasSuffix(u.Path, ".git") || u.Host == "github.com" {
+		return "git-индекс не поддерживается, " + hint
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "адрес не должен содержать параметры запроса, " + hint
+	}
+	return ""
+}
@@ -3,49 +3,49 @@ package nexus
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 
 	"github.com/mkostelcev/nexus-operator/api/v1alpha1"
 )
 
+const repositoriesAPIPath = "/service/rest/v1/repositories"
+
+// Значения по умолчанию для секций, обязательных в API Nexus, как в Nexus.
+const (
+	defaultNugetVersion              = "V3"
+	defaultNugetQueryCacheItemMaxAge = 3600
+	defaultNegativeCacheTimeToLive   = 1440
+	defaultDockerIndexType           = "REGISTRY"
+)
+
+// repositoryEndpoint возвращает адрес API для репозитория указанного типа.
+func repositoryEndpoint(repoType string) (string, error) {
+	format, kind, err := LookupRepositoryFormat(repoType)
+	if err != nil {
+		return "", err
+	}
+	return format.endpoint(kind), nil
+}
+
 // CreateRepository создаёт репозиторий указанного типа.
-func (c *Client) CreateRepository(ctx context.Context, repoType string, config map[string]interface{}) error {
-	var endpoint string
-
-	switch repoType {
-	case TypeMavenHosted:
-		endpoint = "/service/rest/v1/repositories/maven/hosted"
-	case TypeMavenProxy:
-		endpoint = "/service/rest/v1/repositories/maven/proxy"
-	case TypeMavenGroup:
-		endpoint = "/service/rest/v1/repositories/maven/group"
-	case TypeNpmHosted:
-		endpoint = "/service/rest/v1/repositories/npm/hosted"
-	case TypeNpmProxy:
-		endpoint = "/service/rest/v1/repositories/npm/proxy"
-	case TypeNpmGroup:
-		endpoint = "/service/rest/v1/repositories/npm/group"
-	case TypeDockerHosted:
-		endpoint = "/service/rest/v1/repositories/docker/hosted"
-	case TypeDockerProxy:
-		endpoint = "/service/rest/v1/repositories/docker/proxy"
-	case TypeDockerGroup:
-		endpoint = "/service/rest/v1/repositories/docker/group"
-	case TypeRawHosted:
-		endpoint = "/service/rest/v1/repositories/raw/hosted"
-	case TypeRawProxy:
-		endpoint = "/service/rest/v1/repositories/raw/proxy"
-	case TypeRawGroup:
-		endpoint = "/service/rest/v1/repositories/raw/group"
-	default:
-		return fmt.Errorf("%w: %s", ErrUnsupportedRepoType, repoType)
+func (c *Client) CreateRepository(ctx context.Context, repoType string, repo *Repository) error {
+	endpoint, err := repositoryEndpoint(repoType)
+	if err != nil {
+		return err
 	}
 
 	c.Logger.Infof("Создание репозитория типа %s", repoType)
 	resp, err := c.Resty.R().
 		SetContext(ctx).
-		SetBody(config).
+		SetBody(repo).
 		SetHeader("Content-Type", "application/json").
 		Post(endpoint)
 	if err != nil {
@@ -53,83 +53,111 @@ func (c *Client) CreateRepository(ctx context.Context, repoType string, config m
 	}
 
 	if resp.StatusCode() != 201 {
-		return NewUnexpectedResponseError(resp.StatusCode(), resp.String())
+		return NewAPIError(resp)
 	}
 	return nil
 }
 
 // UpdateRepository обновляет существующий репозиторий.
-func (c *Client) UpdateRepository(ctx context.Context, repoType, name string, config map[string]interface{}) error {
-	var endpoint string
-
-	switch repoType {
-	case TypeMavenHosted:
-		endpoint = fmt.Sprintf("/service/rest/v1/repositories/maven/hosted/%s", name)
-	case TypeMavenProxy:
-		endpoint = fmt.Sprintf("/service/rest/v1/repositories/maven/proxy/%s", name)
-	case TypeMavenGroup:
-		endpoint = fmt.Sprintf("/service/rest/v1/repositories/maven/group/%s", name)
-	case TypeNpmHosted:
-		endpoint = fmt.Sprintf("/service/rest/v1/repositories/npm/hosted/%s", name)
-	case TypeNpmProxy:
-		endpoint = fmt.Sprintf("/service/rest/v1/repositories/npm/proxy/%s", name)
-	case TypeNpmGroup:
-		endpoint = fmt.Sprintf("/service/rest/v1/repositories/npm/group/%s", name)
-	case TypeDockerHosted:
-		endpoint = fmt.Sprintf("/service/rest/v1/repositories/docker/hosted/%s", name)
-	case TypeDockerProxy:
-		endpoint = fmt.Sprintf("/service/rest/v1/repositories/docker/proxy/%s", name)
-	case TypeDockerGroup:
-		endpoint = fmt.Sprintf("/service/rest/v1/repositories/docker/group/%s", name)
-	case TypeRawHosted:
-		endpoint = fmt.Sprintf("/service/rest/v1/repositories/raw/hosted/%s", name)
-	case TypeRawProxy:
-		endpoint = fmt.Sprintf("/service/rest/v1/repositories/raw/proxy/%s", name)
-	case TypeRawGroup:
-		endpoint = fmt.Sprintf("/service/rest/v1/repositories/raw/group/%s", name)
-	default:
-		return fmt.Errorf("%w: %s", ErrUnsupportedRepoType, repoType)
+func (c *Client) UpdateRepository(ctx context.Context, repoType string, repo *Repository) error {
+	endpoint, err := repositoryEndpoint(repoType)
+	if err != nil {
+		return err
 	}
 
 	c.Logger.Infof("Обновление репозитория типа %s", repoType)
 	resp, err := c.Resty.R().
 		SetContext(ctx).
-		SetBody(config).
+		SetBody(repo).
 		SetHeader("Content-Type", "application/json").
-		Put(endpoint)
+		SetPathParam("name", repo.Name).
+		Put(endpoint + "/{name}")
 	if err != nil {
 		return fmt.Errorf("ошибка выполнения запроса: %w", err)
 	}
 
 	// Считаем успешными ответы 200 и 204
 	if resp.StatusCode() != 200 && resp.StatusCode() != 204 {
-		return fmt.Errorf("%w: %d", ErrUnexpectedResponse, resp.StatusCode())
+		return NewAPIError(resp)
 	}
 	return nil
 }
 
-// GetRepository получает конфигурацию существующего репозитория.
-func (c *Client) GetRepository(ctx context.Context, name string) (map[string]interface{}, error) {
+// GetRepository получает конфигурацию существующего репозитория указанного типа.
+func (c *Client) GetRepository(ctx context.Context, repoType, name string) (*Repository, error) {
+	endpoint, err := repositoryEndpoint(repoType)
+	if err != nil {
+		return nil, err
+	}
+
 	c.Logger.Infof("Получение конфигурации репозитория: %s", name)
 	resp, err := c.Resty.R().
 		SetContext(ctx).
 		SetPathParam("name", name).
-		Get("/service/rest/v1/repositories/{name}")
+		SetResult(&Repository{}).
+		Get(endpoint + "/{name}")
+	if err != nil {
+		return nil, fmt.Errorf("ошибка запроса: %w", err)
+	}
 
-	if resp.StatusCode() == 404 {
+	switch resp.StatusCode() {
+	case 200:
+		return resp.Result().(*Repository), nil
+	case 404:
 		return nil, ErrRepositoryNotFound
+	default:
+		return nil, NewAPIError(resp)
 	}
+}
 
+// GetRepositorySummary получает общие сведения о репозитории любого типа: имя, формат, вид и адрес.
+// Атрибуты формата в ответе не заполняются.
+func (c *Client) GetRepositorySummary(ctx context.Context, name string) (*Repository, error) {
+	c.Logger.Infof("Получение сведений о репозитории: %s", name)
+	resp, err := c.Resty.R().
+		SetContext(ctx).
+		SetPathParam("name", name).
+		SetResult(&Repository{}).
+		Get(repositoriesAPIPath + "/{name}")
 	if err != nil {
 		return nil, fmt.Errorf("ошибка запроса: %w", err)
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(resp.Body(), &result); err != nil {
-		return nil, fmt.Errorf("ошибка разбора ответа: %w", err)
+	switch resp.StatusCode() {
+	case 200:
+		return resp.Result().(*Repository), nil
+	case 404:
+		return nil, ErrRepositoryNotFound
+	default:
+		return nil, NewAPIError(resp)
 	}
+}
 
-	return result, nil
+// CheckWritableMember проверяет, что участник group.writableMember существует и является
+// hosted-репозиторием формата группы. Nexus отклоняет такую конфигурацию без пояснений,
+// поэтому проверка выполняется до отправки запроса.
+func CheckWritableMember(ctx context.Context, api RepositoryAPI, repoType string, group *RepositoryGroup) error {
+	if group == nil || group.WritableMember == "" {
+		return nil
+	}
+	format, _, err := LookupRepositoryFormat(repoType)
+	if err != nil {
+		return err
+	}
+
+	member, err := api.GetRepositorySummary(ctx, group.WritableMember)
+	if errors.Is(err, ErrRepositoryNotFound) {
+		return fmt.Errorf("%w: участник group.writableMember %s не найден",
+			ErrInvalidRepositorySpec, group.WritableMember)
+	}
+	if err != nil {
+		return fmt.Errorf("ошибка получения участника group.writableMember: %w", err)
+	}
+	if member.Format != format.Name || member.Type != KindHosted {
+		return fmt.Errorf("%w: участник group.writableMember %s должен быть репозиторием %s-%s, получен %s-%s",
+			ErrInvalidRepositorySpec, group.WritableMember, format.Name, KindHosted, member.Format, member.Type)
+	}
+	return nil
 }
 
 // DeleteRepository удаляет репозиторий из Nexus.
@@ -138,7 +166,7 @@ func (c *Client) DeleteRepository(ctx context.Context, name string) error {
 	resp, err := c.Resty.R().
 		SetContext(ctx).
 		SetPathParam("name", name).
-		Delete("/service/rest/v1/repositories/{name}")
+		Delete(repositoriesAPIPath + "/{name}")
 	if err != nil {
 		return fmt.Errorf("ошибка выполнения запроса: %w", err)
 	}
@@ -148,144 +176,277 @@ func (c *Client) DeleteRepository(ctx context.Context, name string) error {
 	}
 
 	if resp.StatusCode() != 204 {
-		return NewUnexpectedResponseError(resp.StatusCode(), resp.String())
+		return NewAPIError(resp)
 	}
 
 	c.Logger.Infof("Репозиторий удалён: %s", name)
 	return nil
 }
 
-// BuildRepositoryConfig создаёт конфигурацию для репозитория указанного типа.
-func BuildRepositoryConfig(repo v1alpha1.Repository) (map[string]interface{}, error) {
-	config := map[string]interface{}{
-		"name":    repo.Spec.Name,
-		"online":  repo.Spec.Online,
-		"storage": repo.Spec.Storage,
-	}
+// SigningKey - ключ GPG для подписи метаданных репозитория.
+type SigningKey struct {
+	Keypair    string
+	Passphrase string
+}
 
-	// Общая обработка proxy-конфигурации
-	if repo.Spec.Proxy != nil {
-		config["proxy"] = buildProxyConfig(repo.Spec.Proxy)
+// Hash возвращает хэш ключа, по которому отслеживается его изменение.
+// Сам ключ в статус и журнал не попадает.
+func (k *SigningKey) Hash() string {
+	if k == nil {
+		return ""
 	}
+	sum := sha256.Sum256([]byte(k.Keypair + "\x00" + k.Passphrase))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
 
-	// Обработка групп
-	if repo.Spec.Group != nil {
-		config["group"] = repo.Spec.Group
+// RemoteCredentials - учётные данные удалённого репозитория.
+type RemoteCredentials struct {
+	Username    string
+	Password    string
+	BearerToken string
+}
+
+// Hash возвращает хэш учётных данных, по которому отслеживается их изменение.
+// Сами учётные данные в статус и журнал не попадают.
+func (c *RemoteCredentials) Hash() string {
+	if c == nil {
+		return ""
 	}
+	sum := sha256.Sum256([]byte(c.Username + "\x00" + c.Password + "\x00" + c.BearerToken))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
 
-	// Общая обработка httpClient и negativeCache
-	if repo.Spec.HttpClient != nil {
-		config["httpClient"] = repo.Spec.HttpClient
+// RepositorySecrets - значения из Secret, на которые ссылается спецификация репозитория.
+type RepositorySecrets struct {
+	SigningKey *SigningKey
+	// RemoteCredentials заменяет учётные данные из спецификации в секции httpClient.authentication.
+	RemoteCredentials *RemoteCredentials
+}
+
+// BuildRepositoryConfig создаёт конфигурацию для репозитория указанного типа.
+// Общие секции заполняются по виду репозитория, атрибуты - описанием формата из реестра.
+func BuildRepositoryConfig(repo v1alpha1.Repository, secrets RepositorySecrets) (*Repository, error) {
+	spec := repo.Spec
+	format, kind, err := LookupRepositoryFormat(spec.Type)
+	if err != nil {
+		return nil, err
 	}
-	if repo.Spec.NegativeCache != nil {
-		config["negativeCache"] = repo.Spec.NegativeCache
+	if err := format.validate(&spec, kind); err != nil {
+		return nil, err
 	}
 
-	// Тип-специфичная конфигурация
-	switch repo.Spec.Type {
-	case TypeMavenProxy:
-		config["maven"] = repo.Spec.Maven
-	case TypeMavenHosted:
-		config["maven"] = repo.Spec.Maven
-	case TypeMavenGroup:
-		if repo.Spec.Maven != nil {
-			config["maven"] = repo.Spec.Maven
+	config := &Repository{
+		Name:   spec.Name,
+		Online: spec.Online,
+		Storage: &RepositoryStorage{
+			BlobStoreName:               spec.Storage.BlobStoreName,
+			StrictContentTypeValidation: spec.Storage.StrictContentTypeValidation,
+		},
+	}
+	if kind != KindGroup {
+		// Секция отправляется и без политик, чтобы снять политики, удалённые из спецификации
+		config.Cleanup = &RepositoryCleanup{PolicyNames: []string{}}
+		if spec.Cleanup != nil {
+			config.Cleanup.PolicyNames = spec.Cleanup.PolicyNames
+		}
+	}
+	switch kind {
+	case KindHosted:
+		// Политика записи применима только к hosted-репозиториям
+		config.Storage.WritePolicy = spec.Storage.WritePolicy
+	case KindProxy:
+		if err := ValidateRemoteURL(spec.Type, spec.Proxy.RemoteUrl); err != nil {
+			return nil, err
 		}
-	case TypeNpmHosted:
-		config["npm"] = repo.Spec.Npm
-	case TypeNpmProxy:
-		config["npm"] = repo.Spec.Npm
-	case TypeNpmGroup:
-		config["storage"] = map[string]interface{}{
-			"blobStoreName":               repo.Spec.Storage.BlobStoreName,
-			"strictContentTypeValidation": repo.Spec.Storage.StrictContentTypeValidation,
-			"writePolicy":                 repo.Spec.Storage.WritePolicy,
+		config.Proxy = buildProxyConfig(spec.Proxy)
+		// Секции httpClient и negativeCache обязательны в API Nexus
+		config.HTTPClient = &RepositoryHTTPClient{AutoBlock: true}
+		if spec.HttpClient != nil {
+			config.HTTPClient = buildHTTPClientConfig(spec.HttpClient, secrets.RemoteCredentials)
 		}
-		config["group"] = repo.Spec.Group
-		if repo.Spec.Npm != nil {
-			config["npm"] = map[string]interface{}{
-				"removeNonCataloged": repo.Spec.Npm.RemoveNonCataloged,
-				"removeQuarantined":  repo.Spec.Npm.RemoveQuarantined,
+		config.NegativeCache = &RepositoryNegativeCache{Enabled: true, TimeToLive: defaultNegativeCacheTimeToLive}
+		if spec.NegativeCache != nil {
+			config.NegativeCache = &RepositoryNegativeCache{
+				Enabled:    spec.NegativeCache.Enabled,
+				TimeToLive: spec.NegativeCache.TimeToLive,
 			}
 		}
-	case TypeDockerProxy:
-		dockerProxyConfig := map[string]interface{}{
-			"httpPort":       repo.Spec.Docker.HttpPort,
-			"httpsPort":      repo.Spec.Docker.HttpsPort,
-			"forceBasicAuth": repo.Spec.Docker.ForceBasicAuth,
-			"v1Enabled":      repo.Spec.Docker.V1Enabled,
-			"subdomain":      repo.Spec.Docker.Subdomain,
+	case KindGroup:
+		config.Group = &RepositoryGroup{
+			MemberNames:    spec.Group.MemberNames,
+			WritableMember: spec.Group.WritableMember,
 		}
-
-		config["docker"] = dockerProxyConfig
-		config["dockerProxy"] = map[string]interface{}{
-			"contentMaxAge":  repo.Spec.Proxy.ContentMaxAge,
-			"metadataMaxAge": repo.Spec.Proxy.MetadataMaxAge,
-			"remoteUrl":      repo.Spec.Proxy.RemoteUrl,
-			"indexType":      "REGISTRY",
+	}
+	if spec.RoutingRule != nil {
+		if kind == KindHosted {
+			return nil, fmt.Errorf("%w: routingRule неприменим к hosted-репозиториям", ErrInvalidRepositorySpec)
 		}
+		config.RoutingRule = spec.RoutingRule.Name
+	}
 
-		if repo.Spec.HttpClient != nil {
-			config["httpClient"] = repo.Spec.HttpClient
-		}
-	case TypeDockerGroup:
-		config["group"] = repo.Spec.Group
-	case TypeDockerHosted:
-		dockerConfig := map[string]interface{}{
-			"forceBasicAuth": repo.Spec.Docker.ForceBasicAuth,
-			"v1Enabled":      repo.Spec.Docker.V1Enabled,
-			"subdomain":      repo.Spec.Docker.Subdomain,
-		}
+	if format.Build != nil {
+		format.Build(&spec, kind, secrets, config)
+	}
+	return config, nil
+}
+
+// buildSigningConfig создаёт конфигурацию ключа подписи.
+func buildSigningConfig(key *SigningKey) *SigningAttributes {
+	return &SigningAttributes{Keypair: key.Keypair, Passphrase: key.Passphrase}
+}
+
+// buildProxyConfig создаёт конфигурацию для proxy.
+func buildProxyConfig(proxy *v1alpha1.ProxyConfig) *RepositoryProxy {
+	return &RepositoryProxy{
+		RemoteURL:      proxy.RemoteUrl,
+		ContentMaxAge:  proxy.ContentMaxAge,
+		MetadataMaxAge: proxy.MetadataMaxAge,
+	}
+}
 
-		if repo.Spec.Docker.HttpPort != nil {
-			dockerConfig["httpPort"] = *repo.Spec.Docker.HttpPort
+// buildHTTPClientConfig создаёт конфигурацию HTTP-клиента, включая аутентификацию.
+// Учётные данные credentials, прочитанные из Secret, заменяют значения из спецификации.
+func buildHTTPClientConfig(httpClient *v1alpha1.HttpClientConfig, credentials *RemoteCredentials) *RepositoryHTTPClient {
+	config := &RepositoryHTTPClient{
+		Blocked:   httpClient.Blocked,
+		AutoBlock: httpClient.AutoBlock,
+	}
+	if conn := httpClient.Connection; conn != nil {
+		config.Connection = &RepositoryHTTPConnection{
+			Retries:                 conn.Retries,
+			UserAgentSuffix:         conn.UserAgentSuffix,
+			Timeout:                 conn.Timeout,
+			EnableCircularRedirects: conn.EnableCircularRedirects,
+			EnableCookies:           conn.EnableCookies,
+			UseTrustStore:           conn.UseTrustStore,
+		}
+	}
+	if auth := httpClient.Authentication; auth != nil {
+		config.Authentication = &RepositoryHTTPAuthentication{
+			Type:        auth.Type,
+			Username:    auth.Username,
+			Password:    auth.Password,
+			NtlmHost:    auth.NtlmHost,
+			NtlmDomain:  auth.NtlmDomain,
+			BearerToken: auth.BearerToken,
+			Preemptive:  auth.Preemptive,
 		}
-		if repo.Spec.Docker.HttpsPort != nil {
-			dockerConfig["httpsPort"] = *repo.Spec.Docker.HttpsPort
+		if credentials != nil {
+			config.Authentication.Username = credentials.Username
+			config.Authentication.Password = credentials.Password
+			config.Authentication.BearerToken = credentials.BearerToken
 		}
+	}
+	return config
+}
 
-		config["docker"] = dockerConfig
-	case TypeRawHosted:
-		config["storage"] = map[string]interface{}{
-			"blobStoreName":               repo.Spec.Storage.BlobStoreName,
-			"strictContentTypeValidation": repo.Spec.Storage.StrictContentTypeValidation,
-			"writePolicy":                 repo.Spec.Storage.WritePolicy,
+// RepositoryDiff возвращает различия между желаемой и текущей конфигурацией репозитория.
+// Пустая строка означает, что обновление не требуется. Не учитываются поля, которые
+// заполняет сервер (format, type, url), пароль, токен и ключи подписи (сервер их не возвращает)
+// и секции, не заданные в желаемой конфигурации. Изменение ключа подписи и учётных данных
+// отслеживается по SigningKey.Hash и RemoteCredentials.Hash.
+func RepositoryDiff(desired, current *Repository) string {
+	normalized := *current
+	normalized.Format, normalized.Type, normalized.URL = "", "", ""
+
+	// Необязательные поля, не заданные в желаемой конфигурации, сервер заполняет своими значениями.
+	if desired.Storage != nil && normalized.Storage != nil {
+		storage := *normalized.Storage
+		if desired.Storage.WritePolicy == "" {
+			storage.WritePolicy = ""
 		}
-	case TypeRawProxy:
-		config["proxy"] = map[string]interface{}{
-			"remoteUrl":      repo.Spec.Proxy.RemoteUrl,
-			"contentMaxAge":  repo.Spec.Proxy.ContentMaxAge,
-			"metadataMaxAge": repo.Spec.Proxy.MetadataMaxAge,
+		if desired.Storage.LatestPolicy == nil {
+			storage.LatestPolicy = nil
 		}
-		if repo.Spec.HttpClient != nil {
-			config["httpClient"] = repo.Spec.HttpClient
+		normalized.Storage = &storage
+	}
+	if desired.Cleanup != nil {
+		// Репозиторий без политик очистки сервер может вернуть без секции cleanup,
+		// а порядок политик не имеет значения.
+		cleanup := RepositoryCleanup{}
+		if normalized.Cleanup != nil {
+			cleanup.PolicyNames = slices.Clone(normalized.Cleanup.PolicyNames)
 		}
-	case TypeRawGroup:
-		config["group"] = map[string]interface{}{
-			"memberNames": repo.Spec.Group.MemberNames,
+		wanted := slices.Clone(desired.Cleanup.PolicyNames)
+		slices.Sort(cleanup.PolicyNames)
+		slices.Sort(wanted)
+		if slices.Equal(cleanup.PolicyNames, wanted) {
+			cleanup.PolicyNames = desired.Cleanup.PolicyNames
+		}
+		normalized.Cleanup = &cleanup
+	}
+	if desired.Docker != nil && normalized.Docker != nil && desired.Docker.PathEnabled == nil {
+		docker := *normalized.Docker
+		docker.PathEnabled = nil
+		normalized.Docker = &docker
+	}
+	if desired.DockerProxy != nil && normalized.DockerProxy != nil && desired.DockerProxy.IndexURL == "" {
+		dockerProxy := *normalized.DockerProxy
+		dockerProxy.IndexURL = ""
+		normalized.DockerProxy = &dockerProxy
+	}
+	if desired.HTTPClient != nil && normalized.HTTPClient != nil {
+		normalized.HTTPClient = normalizeHTTPClient(desired.HTTPClient, normalized.HTTPClient)
+	}
+	// Сервер возвращает правило маршрутизации в поле routingRuleName.
+	if normalized.RoutingRule == "" {
+		normalized.RoutingRule = normalized.RoutingRuleName
+	}
+	normalized.RoutingRuleName = ""
+	// Секции, не заданные в желаемой конфигурации, не сравниваются.
+	d, n := reflect.ValueOf(desired).Elem(), reflect.ValueOf(&normalized).Elem()
+	for i := 0; i < d.NumField(); i++ {
+		if d.Field(i).Kind() == reflect.Ptr && d.Field(i).IsNil() {
+			n.Field(i).Set(reflect.Zero(n.Field(i).Type()))
 		}
-	default:
-		return nil, fmt.Errorf("%w: %s", ErrUnsupportedRepoType, repo.Spec.Type)
 	}
 
-	return config, nil
+	return cmp.Diff(desired, &normalized,
+		cmpopts.EquateEmpty(),
+		cmpopts.IgnoreFields(RepositoryHTTPAuthentication{}, "Password", "BearerToken"),
+		cmpopts.IgnoreFields(Repository{}, "AptSigning", "YumSigning"),
+	)
 }
 
-// buildProxyConfig создаёт конфигурацию для proxy, включая аутентификацию.
-func buildProxyConfig(proxy *v1alpha1.ProxyConfig) map[string]interface{} {
-	proxyConfig := map[string]interface{}{
-		"remoteUrl":      proxy.RemoteUrl,
-		"contentMaxAge":  proxy.ContentMaxAge,
-		"metadataMaxAge": proxy.MetadataMaxAge,
+// normalizeHTTPClient убирает из текущих настроек HTTP-клиента значения, которые сервер заполняет сам:
+// число попыток и время ожидания, не заданные в желаемой конфигурации. Отсутствующая секция соединения
+// равнозначна секции со значениями по умолчанию.
+func normalizeHTTPClient(desired, current *RepositoryHTTPClient) *RepositoryHTTPClient {
+	httpClient := *current
+	conn := RepositoryHTTPConnection{}
+	if httpClient.Connection != nil {
+		conn = *httpClient.Connection
+	}
+	if desired.Connection == nil || desired.Connection.Retries == nil {
+		conn.Retries = nil
+	}
+	if desired.Connection == nil || desired.Connection.Timeout == nil {
+		conn.Timeout = nil
 	}
 
-	return proxyConfig
+	httpClient.Connection = nil
+	if desired.Connection != nil || conn != (RepositoryHTTPConnection{}) {
+		httpClient.Connection = &conn
+	}
+	return &httpClient
+}
+
+// RepositoryCapabilities возвращает возможности Nexus, которые использует спецификация репозитория.
+func RepositoryCapabilities(spec v1alpha1.RepositorySpec) []Capability {
+	format, _, err := LookupRepositoryFormat(spec.Type)
+	if err != nil || format.Capabilities == nil {
+		return nil
+	}
+	return format.Capabilities(&spec)
 }
 
 // RepositoryExists проверяет, существует ли репозиторий.
 func (c *Client) RepositoryExists(ctx context.Context, name string) (bool, error) {
 	c.Logger.Infof("Проверка существования репозитория: %s", name)
-	resp, err := c.Resty.R().SetPathParam("name", name).Get("/service/rest/v1/repositories/{name}")
+	resp, err := c.Resty.R().
+		SetContext(ctx).
+		SetPathParam("name", name).
+		Get(repositoriesAPIPath + "/{name}")
 	if err != nil {
 		return false, fmt.Errorf("ошибка выполнения запроса: %w", err)
 	}
@@ -296,6 +457,6 @@ func (c *Client) RepositoryExists(ctx context.Context, name string) (bool, error
 	case 404:
 		return false, nil
 	default:
-		return false, fmt.Errorf("%w: %d", ErrUnexpectedResponse, resp.StatusCode())
+		return false, NewAPIError(resp)
 	}
 }
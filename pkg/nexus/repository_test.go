@@ -0,0 +1,117 @@
+package nexus_test
+
+import (
+	"testing"
+
+	"github.com/mkostelcev/nexus-operator/pkg/nexus"
+)
+
+func TestRepositoryDiff(t *testing.T) {
+	retries := int32(3)
+	desired := func() *nexus.Repository {
+		return &nexus.Repository{
+			Name:        "npm-proxy",
+			Online:      true,
+			Storage:     &nexus.RepositoryStorage{BlobStoreName: "default"},
+			Cleanup:     &nexus.RepositoryCleanup{PolicyNames: []string{"daily", "weekly"}},
+			Proxy:       &nexus.RepositoryProxy{RemoteURL: "https://registry.npmjs.org", ContentMaxAge: 1440, MetadataMaxAge: 1440},
+			HTTPClient:  &nexus.RepositoryHTTPClient{AutoBlock: true},
+			RoutingRule: "block-internal",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		current func(*nexus.Repository)
+		changed bool
+	}{
+		{
+			name:    "совпадающая конфигурация",
+			current: func(*nexus.Repository) {},
+		},
+		{
+			name: "поля, заполняемые сервером",
+			current: func(r *nexus.Repository) {
+				r.Format, r.Type, r.URL = "npm", "proxy", "http://nexus/repository/npm-proxy"
+				r.Storage.WritePolicy = "ALLOW"
+				r.HTTPClient.Connection = &nexus.RepositoryHTTPConnection{Retries: &retries}
+				r.NegativeCache = &nexus.RepositoryNegativeCache{Enabled: true, TimeToLive: 1440}
+			},
+		},
+		{
+			name: "правило маршрутизации в routingRuleName",
+			current: func(r *nexus.Repository) {
+				r.RoutingRule, r.RoutingRuleName = "", "block-internal"
+			},
+		},
+		{
+			name: "другой порядок политик очистки",
+			current: func(r *nexus.Repository) {
+				r.Cleanup.PolicyNames = []string{"weekly", "daily"}
+			},
+		},
+		{
+			name: "аутентификация задана только на сервере",
+			current: func(r *nexus.Repository) {
+				r.HTTPClient.Authentication = &nexus.RepositoryHTTPAuthentication{Type: "username", Username: "ci"}
+			},
+			changed: true,
+		},
+		{
+			name: "изменён адрес удалённого репозитория",
+			current: func(r *nexus.Repository) {
+				r.Proxy.RemoteURL = "https://registry.example.com"
+			},
+			changed: true,
+		},
+		{
+			name: "удалена политика очистки",
+			current: func(r *nexus.Repository) {
+				r.Cleanup.PolicyNames = []string{"daily"}
+			},
+			changed: true,
+		},
+		{
+			name: "другое правило маршрутизации",
+			current: func(r *nexus.Repository) {
+				r.RoutingRule, r.RoutingRuleName = "", "allow-all"
+			},
+			changed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := desired()
+			tt.current(current)
+
+			diff := nexus.RepositoryDiff(desired(), current)
+			if got := diff != ""; got != tt.changed {
+				t.Errorf("изменения обнаружены: %t, ожидалось %t\n%s", got, tt.changed, diff)
+			}
+		})
+	}
+}
+
+func TestRepositoryDiffIgnoresSecrets(t *testing.T) {
+	desired := &nexus.Repository{
+		Name:    "raw-proxy",
+		Storage: &nexus.RepositoryStorage{BlobStoreName: "default"},
+		HTTPClient: &nexus.RepositoryHTTPClient{
+			Authentication: &nexus.RepositoryHTTPAuthentication{Type: "username", Username: "ci", Password: "secret"},
+		},
+		YumSigning: &nexus.SigningAttributes{Keypair: "keypair"},
+	}
+	// Nexus не возвращает пароли и ключи подписи.
+	current := &nexus.Repository{
+		Name:    "raw-proxy",
+		Storage: &nexus.RepositoryStorage{BlobStoreName: "default"},
+		HTTPClient: &nexus.RepositoryHTTPClient{
+			Authentication: &nexus.RepositoryHTTPAuthentication{Type: "username", Username: "ci"},
+		},
+	}
+
+	if diff := nexus.RepositoryDiff(desired, current); diff != "" {
+		t.Errorf("секреты не должны сравниваться:\n%s", diff)
+	}
+}
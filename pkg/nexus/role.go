@@ -34,7 +34,7 @@ func (c *Client) GetRole(ctx context.Context, roleID string) (*Role, error) {
 	case 404:
 		return nil, ErrRoleNotFound
 	default:
-		return nil, NewUnexpectedResponseError(resp.StatusCode(), resp.String())
+		return nil, NewAPIError(resp)
 	}
 }
 
@@ -73,7 +73,7 @@ func (c *Client) CreateRole(ctx context.Context, role Role) error {
 	}
 
 	if resp.StatusCode() != 201 {
-		return NewUnexpectedResponseError(resp.StatusCode(), resp.String())
+		return NewAPIError(resp)
 	}
 
 	c.Logger.WithFields(logFields).Info("Роль успешно создана")
@@ -99,7 +99,7 @@ func (c *Client) UpdateRole(ctx context.Context, roleID string, role Role) error
 	}
 
 	if resp.StatusCode() != 204 {
-		return NewUnexpectedResponseError(resp.StatusCode(), resp.String())
+		return NewAPIError(resp)
 	}
 
 	c.Logger.WithFields(logFields).Info("Роль успешно обновлена")
@@ -128,7 +128,7 @@ func (c *Client) DeleteRole(ctx context.Context, roleID string) error {
 	}
 
 	if resp.StatusCode() != 204 {
-		return NewUnexpectedResponseError(resp.StatusCode(), resp.String())
+		return NewAPIError(resp)
 	}
 
 	c.Logger.WithFields(logFields).Info("Роль успешно удалена")
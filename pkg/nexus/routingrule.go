@@ -0,0 +1,119 @@
+// Работа с правилами маршрутизации в Sonatype Nexus
+package nexus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/mkostelcev/nexus-operator/api/v1alpha1"
+)
+
+// RoutingRuleExists проверяет, существует ли правило маршрутизации.
+func (c *Client) RoutingRuleExists(ctx context.Context, name string) (bool, error) {
+	_, err := c.GetRoutingRule(ctx, name)
+	if errors.Is(err, ErrRoutingRuleNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// GetRoutingRule получает конфигурацию правила маршрутизации.
+func (c *Client) GetRoutingRule(ctx context.Context, name string) (*RoutingRule, error) {
+	resp, err := c.Resty.R().
+		SetContext(ctx).
+		SetPathParam("name", name).
+		SetResult(&RoutingRule{}).
+		Get("/service/rest/v1/routing-rules/{name}")
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		return resp.Result().(*RoutingRule), nil
+	case 404:
+		return nil, ErrRoutingRuleNotFound
+	default:
+		return nil, NewAPIError(resp)
+	}
+}
+
+// CreateRoutingRule создаёт правило маршрутизации.
+func (c *Client) CreateRoutingRule(ctx context.Context, rule *RoutingRule) error {
+	c.Logger.Infof("Создание правила маршрутизации %s", rule.Name)
+	resp, err := c.Resty.R().
+		SetContext(ctx).
+		SetBody(rule).
+		SetHeader("Content-Type", "application/json").
+		Post("/service/rest/v1/routing-rules")
+	if err != nil {
+		return fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+
+	if resp.StatusCode() >= 200 && resp.StatusCode() < 300 {
+		return nil
+	}
+	return NewAPIError(resp)
+}
+
+// UpdateRoutingRule обновляет существующее правило маршрутизации.
+func (c *Client) UpdateRoutingRule(ctx context.Context, name string, rule *RoutingRule) error {
+	c.Logger.Infof("Обновление правила маршрутизации %s", name)
+	resp, err := c.Resty.R().
+		SetContext(ctx).
+		SetPathParam("name", name).
+		SetBody(rule).
+		SetHeader("Content-Type", "application/json").
+		Put("/service/rest/v1/routing-rules/{name}")
+	if err != nil {
+		return fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+
+	if resp.StatusCode() >= 200 && resp.StatusCode() < 300 {
+		return nil
+	}
+	return NewAPIError(resp)
+}
+
+// DeleteRoutingRule удаляет правило маршрутизации.
+func (c *Client) DeleteRoutingRule(ctx context.Context, name string) error {
+	resp, err := c.Resty.R().
+		SetContext(ctx).
+		SetPathParam("name", name).
+		Delete("/service/rest/v1/routing-rules/{name}")
+	if err != nil {
+		return fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+
+	switch resp.StatusCode() {
+	case 200, 204:
+		return nil
+	case 400:
+		// Nexus отклоняет удаление правила, назначенного репозиториям.
+		return fmt.Errorf("%w: %w", ErrRoutingRuleInUse, NewAPIError(resp))
+	case 404:
+		return ErrRoutingRuleNotFound
+	default:
+		return NewAPIError(resp)
+	}
+}
+
+// BuildRoutingRuleConfig создаёт конфигурацию правила маршрутизации.
+func BuildRoutingRuleConfig(spec v1alpha1.RoutingRuleSpec) *RoutingRule {
+	return &RoutingRule{
+		Name:        spec.Name,
+		Description: spec.Description,
+		Mode:        spec.Mode,
+		Matchers:    spec.Matchers,
+	}
+}
+
+// RoutingRuleDiff возвращает различия между желаемым и текущим правилом маршрутизации.
+// Пустая строка означает, что обновление не требуется. Порядок выражений учитывается.
+func RoutingRuleDiff(desired, current *RoutingRule) string {
+	return cmp.Diff(desired, current, cmpopts.EquateEmpty())
+}
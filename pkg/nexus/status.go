@@ -0,0 +1,43 @@
+// Проверка состояния Sonatype Nexus
+package nexus
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	statusAPIPath         = "/service/rest/v1/status"
+	statusWritableAPIPath = "/service/rest/v1/status/writable"
+)
+
+// CheckStatus проверяет, что Nexus отвечает на запросы и принимает запись.
+// Возвращает ErrNexusReadOnly, если экземпляр доступен только для чтения.
+// Запросы выполняются без повторов и не учитываются автоматическим выключателем.
+func (c *Client) CheckStatus(ctx context.Context) error {
+	resp, err := c.probe.R().
+		SetContext(ctx).
+		Get(statusAPIPath)
+	if err != nil {
+		return fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+	if resp.StatusCode() != 200 {
+		return NewAPIError(resp)
+	}
+
+	resp, err = c.probe.R().
+		SetContext(ctx).
+		Get(statusWritableAPIPath)
+	if err != nil {
+		return fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		return nil
+	case 503:
+		return ErrNexusReadOnly
+	default:
+		return NewAPIError(resp)
+	}
+}
@@ -0,0 +1,70 @@
+// Настройки TLS для клиента Sonatype Nexus
+package nexus
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+)
+
+// TLSConfig описывает параметры TLS-соединения с Nexus.
+type TLSConfig struct {
+	// CAPEM - PEM-бандл дополнительных доверенных центров сертификации.
+	CAPEM []byte
+	// CertPEM и KeyPEM - клиентский сертификат и ключ для mTLS.
+	CertPEM []byte
+	KeyPEM  []byte
+	// ServerName переопределяет имя сервера для SNI и проверки сертификата.
+	ServerName string
+	// MinVersion - минимальная версия TLS (tls.VersionTLS12, tls.VersionTLS13).
+	MinVersion uint16
+	// InsecureSkipVerify отключает проверку сертификата сервера.
+	InsecureSkipVerify bool
+}
+
+// build создаёт *tls.Config из параметров.
+func (t *TLSConfig) build() (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         t.ServerName,
+		InsecureSkipVerify: t.InsecureSkipVerify,
+	}
+	if t.MinVersion != 0 {
+		cfg.MinVersion = t.MinVersion
+	}
+
+	if len(t.CAPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(t.CAPEM) {
+			return nil, fmt.Errorf("%w: в CA-бандле не найдено ни одного сертификата", ErrInvalidConfig)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if len(t.CertPEM) > 0 || len(t.KeyPEM) > 0 {
+		cert, err := tls.X509KeyPair(t.CertPEM, t.KeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("%w: ошибка загрузки клиентского сертификата: %w", ErrInvalidConfig, err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// ParseTLSVersion преобразует значение из спецификации (TLS12, TLS13) в константу crypto/tls.
+func ParseTLSVersion(version string) (uint16, error) {
+	switch version {
+	case "":
+		return 0, nil
+	case "TLS12":
+		return tls.VersionTLS12, nil
+	case "TLS13":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("%w: неподдерживаемая версия TLS %s", ErrInvalidConfig, version)
+	}
+}
@@ -22,3 +22,261 @@ type Role struct {
 	Privileges  []string `json:"privileges"`
 	Roles       []string `json:"roles"`
 }
+
+// Repository - конфигурация репозитория в формате API Nexus.
+// Общие поля совпадают для всех форматов, секции атрибутов заполняются в зависимости от формата.
+type Repository struct {
+	Name   string `json:"name"`
+	Online bool   `json:"online"`
+
+	// Format, Type и URL возвращаются сервером и не отправляются в запросах.
+	Format string `json:"format,omitempty"`
+	Type   string `json:"type,omitempty"`
+	URL    string `json:"url,omitempty"`
+
+	Storage       *RepositoryStorage       `json:"storage,omitempty"`
+	Cleanup       *RepositoryCleanup       `json:"cleanup,omitempty"`
+	Proxy         *RepositoryProxy         `json:"proxy,omitempty"`
+	NegativeCache *RepositoryNegativeCache `json:"negativeCache,omitempty"`
+	HTTPClient    *RepositoryHTTPClient    `json:"httpClient,omitempty"`
+	Group         *RepositoryGroup         `json:"group,omitempty"`
+
+	// RoutingRule задаётся в запросах для proxy- и group-репозиториев, а сервер возвращает
+	// применённое правило в RoutingRuleName.
+	RoutingRule     string `json:"routingRule,omitempty"`
+	RoutingRuleName string `json:"routingRuleName,omitempty"`
+
+	Maven       *MavenAttributes       `json:"maven,omitempty"`
+	Npm         *NpmAttributes         `json:"npm,omitempty"`
+	Docker      *DockerAttributes      `json:"docker,omitempty"`
+	DockerProxy *DockerProxyAttributes `json:"dockerProxy,omitempty"`
+	Raw         *RawAttributes         `json:"raw,omitempty"`
+	Pypi        *PypiAttributes        `json:"pypi,omitempty"`
+	NugetProxy  *NugetProxyAttributes  `json:"nugetProxy,omitempty"`
+	Apt         *AptAttributes         `json:"apt,omitempty"`
+	AptSigning  *SigningAttributes     `json:"aptSigning,omitempty"`
+	Yum         *YumAttributes         `json:"yum,omitempty"`
+	YumSigning  *SigningAttributes     `json:"yumSigning,omitempty"`
+}
+
+// RepositoryStorage - настройки хранения. WritePolicy задаётся только для hosted-репозиториев.
+type RepositoryStorage struct {
+	BlobStoreName               string `json:"blobStoreName"`
+	StrictContentTypeValidation bool   `json:"strictContentTypeValidation"`
+	WritePolicy                 string `json:"writePolicy,omitempty"`
+	// LatestPolicy задаётся только для docker-hosted.
+	LatestPolicy *bool `json:"latestPolicy,omitempty"`
+}
+
+// RepositoryCleanup - политики очистки репозитория.
+type RepositoryCleanup struct {
+	PolicyNames []string `json:"policyNames"`
+}
+
+// RepositoryProxy - параметры удалённого репозитория.
+type RepositoryProxy struct {
+	RemoteURL      string `json:"remoteUrl"`
+	ContentMaxAge  int    `json:"contentMaxAge"`
+	MetadataMaxAge int    `json:"metadataMaxAge"`
+}
+
+// RepositoryNegativeCache - настройки отрицательного кэша.
+type RepositoryNegativeCache struct {
+	Enabled    bool `json:"enabled"`
+	TimeToLive int  `json:"timeToLive"`
+}
+
+// RepositoryHTTPClient - настройки HTTP-клиента proxy-репозитория.
+type RepositoryHTTPClient struct {
+	Blocked        bool                          `json:"blocked"`
+	AutoBlock      bool                          `json:"autoBlock"`
+	Connection     *RepositoryHTTPConnection     `json:"connection,omitempty"`
+	Authentication *RepositoryHTTPAuthentication `json:"authentication,omitempty"`
+}
+
+// RepositoryHTTPConnection - настройки соединения с удалённым репозиторием.
+type RepositoryHTTPConnection struct {
+	Retries                 *int32 `json:"retries,omitempty"`
+	UserAgentSuffix         string `json:"userAgentSuffix,omitempty"`
+	Timeout                 *int32 `json:"timeout,omitempty"`
+	EnableCircularRedirects bool   `json:"enableCircularRedirects"`
+	EnableCookies           bool   `json:"enableCookies"`
+	UseTrustStore           bool   `json:"useTrustStore"`
+}
+
+// RepositoryHTTPAuthentication - аутентификация на удалённом репозитории.
+// Пароль и токен сервер не возвращает, поэтому при сравнении они не учитываются.
+type RepositoryHTTPAuthentication struct {
+	Type        string `json:"type"`
+	Username    string `json:"username,omitempty"`
+	Password    string `json:"password,omitempty"`
+	NtlmHost    string `json:"ntlmHost,omitempty"`
+	NtlmDomain  string `json:"ntlmDomain,omitempty"`
+	BearerToken string `json:"bearerToken,omitempty"`
+	Preemptive  bool   `json:"preemptive"`
+}
+
+// RepositoryGroup - участники группового репозитория.
+// WritableMember поддерживается только для docker-group.
+type RepositoryGroup struct {
+	MemberNames    []string `json:"memberNames"`
+	WritableMember string   `json:"writableMember,omitempty"`
+}
+
+// MavenAttributes - атрибуты формата maven2.
+type MavenAttributes struct {
+	VersionPolicy      string `json:"versionPolicy,omitempty"`
+	LayoutPolicy       string `json:"layoutPolicy,omitempty"`
+	ContentDisposition string `json:"contentDisposition,omitempty"`
+}
+
+// NpmAttributes - атрибуты формата npm.
+type NpmAttributes struct {
+	RemoveNonCataloged bool `json:"removeNonCataloged"`
+	RemoveQuarantined  bool `json:"removeQuarantined"`
+}
+
+// DockerAttributes - атрибуты формата docker.
+type DockerAttributes struct {
+	V1Enabled      bool   `json:"v1Enabled"`
+	ForceBasicAuth bool   `json:"forceBasicAuth"`
+	HTTPPort       *int   `json:"httpPort,omitempty"`
+	HTTPSPort      *int   `json:"httpsPort,omitempty"`
+	Subdomain      string `json:"subdomain,omitempty"`
+	PathEnabled    *bool  `json:"pathEnabled,omitempty"`
+}
+
+// DockerProxyAttributes - атрибуты docker-proxy.
+type DockerProxyAttributes struct {
+	IndexType                string   `json:"indexType"`
+	IndexURL                 string   `json:"indexUrl,omitempty"`
+	CacheForeignLayers       bool     `json:"cacheForeignLayers"`
+	ForeignLayerURLWhitelist []string `json:"foreignLayerUrlWhitelist,omitempty"`
+}
+
+// RawAttributes - атрибуты формата raw.
+type RawAttributes struct {
+	ContentDisposition string `json:"contentDisposition,omitempty"`
+}
+
+// PypiAttributes - атрибуты pypi-proxy.
+type PypiAttributes struct {
+	RemoveQuarantined bool `json:"removeQuarantined"`
+}
+
+// NugetProxyAttributes - атрибуты nuget-proxy.
+type NugetProxyAttributes struct {
+	NugetVersion         string `json:"nugetVersion"`
+	QueryCacheItemMaxAge int    `json:"queryCacheItemMaxAge"`
+}
+
+// AptAttributes - атрибуты формата apt. Flat задаётся только для apt-proxy.
+type AptAttributes struct {
+	Distribution string `json:"distribution"`
+	Flat         *bool  `json:"flat,omitempty"`
+}
+
+// YumAttributes - атрибуты yum-hosted.
+type YumAttributes struct {
+	RepodataDepth int    `json:"repodataDepth"`
+	DeployPolicy  string `json:"deployPolicy,omitempty"`
+}
+
+// SigningAttributes - ключ GPG для подписи метаданных.
+// Nexus не возвращает ключ, поэтому при сравнении он не учитывается.
+type SigningAttributes struct {
+	Keypair    string `json:"keypair"`
+	Passphrase string `json:"passphrase,omitempty"`
+}
+
+// Privilege - привилегия в формате API Nexus.
+// Заполняются только поля, относящиеся к типу привилегии.
+type Privilege struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// ReadOnly возвращается сервером для встроенных привилегий.
+	ReadOnly bool `json:"readOnly,omitempty"`
+
+	// wildcard
+	Pattern string `json:"pattern,omitempty"`
+	// application
+	Domain string `json:"domain,omitempty"`
+	// application, repository-view, repository-admin, repository-content-selector
+	Actions []string `json:"actions,omitempty"`
+	// repository-view, repository-admin, repository-content-selector
+	Format     string `json:"format,omitempty"`
+	Repository string `json:"repository,omitempty"`
+	// repository-content-selector
+	ContentSelector string `json:"contentSelector,omitempty"`
+	// script
+	ScriptName string `json:"scriptName,omitempty"`
+}
+
+// CleanupPolicy - политика очистки в формате API Nexus.
+// Критерии, не заданные в политике, не отправляются.
+type CleanupPolicy struct {
+	Name                    string `json:"name"`
+	Format                  string `json:"format"`
+	Notes                   string `json:"notes,omitempty"`
+	CriteriaLastBlobUpdated *int   `json:"criteriaLastBlobUpdated,omitempty"`
+	CriteriaLastDownloaded  *int   `json:"criteriaLastDownloaded,omitempty"`
+	CriteriaReleaseType     string `json:"criteriaReleaseType,omitempty"`
+	CriteriaAssetRegex      string `json:"criteriaAssetRegex,omitempty"`
+}
+
+// BlobStore - хранилище blob-объектов в формате API Nexus.
+// Заполняются только поля, относящиеся к типу хранилища.
+type BlobStore struct {
+	// Type определяет путь API и не отправляется в запросах.
+	Type      string              `json:"-"`
+	Name      string              `json:"name,omitempty"`
+	SoftQuota *BlobStoreSoftQuota `json:"softQuota,omitempty"`
+
+	// File
+	Path string `json:"path,omitempty"`
+	// S3
+	BucketConfiguration *S3BucketConfiguration `json:"bucketConfiguration,omitempty"`
+}
+
+// BlobStoreSoftQuota - мягкая квота хранилища. Limit задаётся в мегабайтах.
+type BlobStoreSoftQuota struct {
+	Type  string `json:"type"`
+	Limit int64  `json:"limit"`
+}
+
+// S3BucketConfiguration - настройки бакета S3.
+type S3BucketConfiguration struct {
+	Bucket                   S3Bucket                    `json:"bucket"`
+	BucketSecurity           *S3BucketSecurity           `json:"bucketSecurity,omitempty"`
+	AdvancedBucketConnection *S3AdvancedBucketConnection `json:"advancedBucketConnection,omitempty"`
+}
+
+// S3Bucket - бакет S3.
+type S3Bucket struct {
+	Region     string `json:"region"`
+	Name       string `json:"name"`
+	Prefix     string `json:"prefix,omitempty"`
+	Expiration int    `json:"expiration"`
+}
+
+// S3BucketSecurity - ключ доступа к бакету.
+// Секретный ключ сервер не возвращает, поэтому при сравнении он не учитывается.
+type S3BucketSecurity struct {
+	AccessKeyID     string `json:"accessKeyId"`
+	SecretAccessKey string `json:"secretAccessKey,omitempty"`
+}
+
+// S3AdvancedBucketConnection - подключение к S3-совместимому сервису.
+type S3AdvancedBucketConnection struct {
+	Endpoint       string `json:"endpoint,omitempty"`
+	ForcePathStyle bool   `json:"forcePathStyle"`
+}
+
+// RoutingRule - правило маршрутизации в формате API Nexus.
+type RoutingRule struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Mode        string   `json:"mode"`
+	Matchers    []string `json:"matchers"`
+}
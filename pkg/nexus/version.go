@@ -0,0 +1,210 @@
+// Определение версии и редакции Sonatype Nexus и матрица возможностей
+package nexus
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Редакции Nexus.
+const (
+	EditionOSS       = "OSS"
+	EditionPro       = "PRO"
+	EditionCommunity = "COMMUNITY"
+)
+
+// serverInfoTTL - время, по истечении которого версия определяется заново (например, после обновления Nexus).
+const serverInfoTTL = 10 * time.Minute
+
+// serverHeaderPattern разбирает заголовок Server вида "Nexus/3.61.0-02 (OSS)".
+var serverHeaderPattern = regexp.MustCompile(`Nexus/(\d+)\.(\d+)\.(\d+)(\S*)\s*(?:\((\w+)\))?`)
+
+// ServerInfo - версия и редакция экземпляра Nexus.
+type ServerInfo struct {
+	// Version - полная версия, например 3.61.0-02.
+	Version string
+	// Edition - редакция: OSS, PRO или COMMUNITY.
+	Edition string
+
+	Major, Minor, Patch int
+}
+
+// Known сообщает, удалось ли определить версию.
+func (i *ServerInfo) Known() bool {
+	return i != nil && i.Version != ""
+}
+
+// IsPro сообщает, является ли экземпляр редакцией Pro.
+func (i *ServerInfo) IsPro() bool {
+	return i != nil && i.Edition == EditionPro
+}
+
+// AtLeast сообщает, что версия Nexus не ниже major.minor.
+func (i *ServerInfo) AtLeast(major, minor int) bool {
+	if i.Major != major {
+		return i.Major > major
+	}
+	return i.Minor >= minor
+}
+
+func (i *ServerInfo) String() string {
+	if !i.Known() {
+		return "неизвестная версия"
+	}
+	return fmt.Sprintf("%s (%s)", i.Version, i.Edition)
+}
+
+// parseServerHeader извлекает версию и редакцию из заголовка Server.
+func parseServerHeader(header string) (*ServerInfo, bool) {
+	m := serverHeaderPattern.FindStringSubmatch(header)
+	if m == nil {
+		return nil, false
+	}
+
+	info := &ServerInfo{
+		Version: m[1] + "." + m[2] + "." + m[3] + m[4],
+		Edition: strings.ToUpper(m[5]),
+	}
+	info.Major, _ = strconv.Atoi(m[1])
+	info.Minor, _ = strconv.Atoi(m[2])
+	info.Patch, _ = strconv.Atoi(m[3])
+	if info.Edition == "" {
+		info.Edition = EditionOSS
+	}
+	return info, true
+}
+
+// serverInfoCache хранит определённую версию экземпляра.
+type serverInfoCache struct {
+	mu         sync.Mutex
+	info       *ServerInfo
+	detectedAt time.Time
+}
+
+// ServerInfo возвращает версию и редакцию Nexus, определяя их при первом обращении
+// и повторно по истечении serverInfoTTL. Если Nexus не сообщает версию
+// (например, заголовок Server скрыт прокси), возвращается пустой ServerInfo.
+func (c *Client) ServerInfo(ctx context.Context) (*ServerInfo, error) {
+	c.serverInfo.mu.Lock()
+	defer c.serverInfo.mu.Unlock()
+
+	if c.serverInfo.info != nil && time.Since(c.serverInfo.detectedAt) < serverInfoTTL {
+		return c.serverInfo.info, nil
+	}
+
+	resp, err := c.Resty.R().
+		SetContext(ctx).
+		Get(statusAPIPath)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+	if resp.StatusCode() != 200 {
+		return nil, NewAPIError(resp)
+	}
+
+	info, ok := parseServerHeader(resp.Header().Get("Server"))
+	if !ok {
+		c.Logger.Warn("Не удалось определить версию Nexus по заголовку Server")
+		info = &ServerInfo{}
+	}
+
+	c.serverInfo.info = info
+	c.serverInfo.detectedAt = time.Now()
+	return info, nil
+}
+
+// Capability - возможность Nexus, зависящая от версии или редакции.
+type Capability string
+
+// Возможности Nexus.
+const (
+	CapabilityNpmRemoveQuarantined      Capability = "npm.removeQuarantined"
+	CapabilityNpmRemoveNonCataloged     Capability = "npm.removeNonCataloged"
+	CapabilityDockerSubdomain           Capability = "docker.subdomain"
+	CapabilityDockerGroupWritableMember Capability = "docker.group.writableMember"
+	CapabilityPypiRemoveQuarantined     Capability = "pypi.removeQuarantined"
+	CapabilityCargo                     Capability = "cargo"
+	CapabilityCleanupPolicies           Capability = "cleanupPolicies"
+)
+
+// capabilityRequirement - минимальная версия и редакция для возможности.
+type capabilityRequirement struct {
+	major, minor int
+	pro          bool
+	// proMajor, proMinor - минимальная версия для Pro, если Pro получила возможность раньше остальных редакций.
+	proMajor, proMinor int
+}
+
+// capabilities - матрица возможностей Nexus.
+var capabilities = map[Capability]capabilityRequirement{
+	CapabilityNpmRemoveQuarantined:      {major: 3, minor: 29, pro: true},
+	CapabilityNpmRemoveNonCataloged:     {major: 3, minor: 29, pro: true},
+	CapabilityDockerSubdomain:           {major: 3, minor: 44, pro: true},
+	CapabilityDockerGroupWritableMember: {major: 3, minor: 30, pro: true},
+	CapabilityPypiRemoveQuarantined:     {major: 3, minor: 29, pro: true},
+	CapabilityCargo:                     {major: 3, minor: 73},
+	CapabilityCleanupPolicies:           {major: 3, minor: 70, proMajor: 3},
+}
+
+// Supports сообщает, поддерживает ли экземпляр возможность.
+// Если версию определить не удалось, возможность считается поддерживаемой.
+func (i *ServerInfo) Supports(capability Capability) bool {
+	req, ok := capabilities[capability]
+	if !ok || !i.Known() {
+		return true
+	}
+	if req.pro && !i.IsPro() {
+		return false
+	}
+	if req.proMajor != 0 && i.IsPro() {
+		return i.AtLeast(req.proMajor, req.proMinor)
+	}
+	return i.AtLeast(req.major, req.minor)
+}
+
+// UnsupportedError - настройки, которые не поддерживает экземпляр Nexus.
+type UnsupportedError struct {
+	Capabilities []Capability
+	Server       *ServerInfo
+}
+
+func (e *UnsupportedError) Error() string {
+	names := make([]string, 0, len(e.Capabilities))
+	for _, c := range e.Capabilities {
+		req := capabilities[c]
+		name := fmt.Sprintf("%s (требуется Nexus %d.%d+", c, req.major, req.minor)
+		if req.pro {
+			name += " Pro"
+		}
+		if req.proMajor != 0 {
+			name += fmt.Sprintf(" или %d.%d+ Pro", req.proMajor, req.proMinor)
+		}
+		names = append(names, name+")")
+	}
+	return fmt.Sprintf("%s: %s, сервер: %s", ErrUnsupported, strings.Join(names, ", "), e.Server)
+}
+
+// Unwrap позволяет проверять ошибку через errors.Is(err, ErrUnsupported).
+func (e *UnsupportedError) Unwrap() error {
+	return ErrUnsupported
+}
+
+// CheckCapabilities возвращает UnsupportedError, если экземпляр не поддерживает
+// хотя бы одну из используемых возможностей.
+func (i *ServerInfo) CheckCapabilities(used []Capability) error {
+	var unsupported []Capability
+	for _, c := range used {
+		if !i.Supports(c) {
+			unsupported = append(unsupported, c)
+		}
+	}
+	if len(unsupported) == 0 {
+		return nil
+	}
+	return &UnsupportedError{Capabilities: unsupported, Server: i}
+}
@@ -0,0 +1,48 @@
+package nexus_test
+
+import (
+	"testing"
+
+	"github.com/mkostelcev/nexus-operator/pkg/nexus"
+)
+
+func TestSupportsCleanupPolicies(t *testing.T) {
+	tests := []struct {
+		name string
+		info *nexus.ServerInfo
+		want bool
+	}{
+		{
+			name: "неизвестная версия",
+			info: &nexus.ServerInfo{},
+			want: true,
+		},
+		{
+			name: "OSS до 3.70",
+			info: &nexus.ServerInfo{Version: "3.69.0-02", Edition: nexus.EditionOSS, Major: 3, Minor: 69},
+		},
+		{
+			name: "OSS 3.70",
+			info: &nexus.ServerInfo{Version: "3.70.0-01", Edition: nexus.EditionOSS, Major: 3, Minor: 70},
+			want: true,
+		},
+		{
+			name: "Community 3.77",
+			info: &nexus.ServerInfo{Version: "3.77.0-08", Edition: nexus.EditionCommunity, Major: 3, Minor: 77},
+			want: true,
+		},
+		{
+			name: "Pro до 3.70",
+			info: &nexus.ServerInfo{Version: "3.61.0-02", Edition: nexus.EditionPro, Major: 3, Minor: 61},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.info.Supports(nexus.CapabilityCleanupPolicies); got != tt.want {
+				t.Errorf("Supports(%s) = %t, ожидалось %t", nexus.CapabilityCleanupPolicies, got, tt.want)
+			}
+		})
+	}
+}